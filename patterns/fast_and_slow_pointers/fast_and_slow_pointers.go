@@ -131,6 +131,80 @@ func IsHappy(num int) bool {
 	return false
 }
 
+// HasCycle checks whether a given linked list has a cycle.
+// Uses a fast and slow pointers pattern, the fast pointer catches up with the slow pointer only if there is a cycle.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func HasCycle(head *structs.LinkedListNode) bool {
+	return meetingPoint(head) != nil
+}
+
+// CycleStart returns the node where the cycle of a given linked list starts, or nil if there is no cycle.
+// Uses a fast and slow pointers pattern to find the meeting point, then moves one pointer back to the head,
+// both pointers moving one step at a time meet again at the start of the cycle.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func CycleStart(head *structs.LinkedListNode) *structs.LinkedListNode {
+	meeting := meetingPoint(head)
+	if meeting == nil {
+		return nil
+	}
+
+	// move the slow pointer to the head and keep the fast pointer at the meeting point
+	// move both one steps at a time until they reach the entry point
+	slow, fast := head, meeting
+	for slow != fast {
+		slow = slow.Next
+		fast = fast.Next
+	}
+	return slow
+}
+
+// CycleLength returns the number of nodes in the cycle of a given linked list, or 0 if there is no cycle.
+// Uses a fast and slow pointers pattern to find a node inside the cycle, then walks around the cycle once.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func CycleLength(head *structs.LinkedListNode) int {
+	meeting := meetingPoint(head)
+	if meeting == nil {
+		return 0
+	}
+
+	length := 1
+	for node := meeting.Next; node != meeting; node = node.Next {
+		length++
+	}
+	return length
+}
+
+// BreakCycle removes the cycle of a given linked list by unlinking the last node of the cycle from the cycle start.
+// It returns true if a cycle was broken, otherwise false.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func BreakCycle(head *structs.LinkedListNode) bool {
+	start := CycleStart(head)
+	if start == nil {
+		return false
+	}
+
+	// walk around the cycle until reaching the node pointing back to the cycle start
+	last := start
+	for last.Next != start {
+		last = last.Next
+	}
+	last.Next = nil
+	return true
+}
+
+// meetingPoint returns the node where the fast and slow pointers meet, or nil if the linked list has no cycle
+func meetingPoint(head *structs.LinkedListNode) *structs.LinkedListNode {
+	slow, fast := head, head
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+		if slow == fast {
+			return slow
+		}
+	}
+	return nil
+}
+
 func nextIndex(currentIndex int, indexValue int, arraySize int) int {
 	nextIdx := currentIndex + indexValue
 	if nextIdx < 0 {
@@ -208,3 +208,124 @@ func TestIsHappy(t *testing.T) {
 		}
 	}
 }
+
+// createCyclicLinkedList creates a linked list from nums with its last node linked back to the node at index pos,
+// no cycle is created if pos is negative
+func createCyclicLinkedList(nums []int, pos int) *structs.LinkedList {
+	ll := &structs.LinkedList{}
+	ll.CreateLinkedList(nums)
+	if pos < 0 {
+		return ll
+	}
+
+	var target, last *structs.LinkedListNode
+	for i, node := 0, ll.Head; node != nil; i, node = i+1, node.Next {
+		if i == pos {
+			target = node
+		}
+		last = node
+	}
+	last.Next = target
+	return ll
+}
+
+func TestCycleUtilities(t *testing.T) {
+	testCases := []struct {
+		name           string
+		nums           []int
+		pos            int
+		hasCycle       bool
+		startIndex     int
+		length         int
+		expectedString string
+	}{
+		{
+			name:           "Case 1",
+			nums:           []int{1, 2, 3},
+			pos:            1,
+			hasCycle:       true,
+			startIndex:     1,
+			length:         2,
+			expectedString: "[1, 2, 3 -> (back to 2)]",
+		},
+		{
+			name:           "Case 2",
+			nums:           []int{1, 2, 3, 4, 5},
+			pos:            -1,
+			hasCycle:       false,
+			startIndex:     -1,
+			length:         0,
+			expectedString: "[1, 2, 3, 4, 5]",
+		},
+		{
+			name:           "Case 3",
+			nums:           []int{7},
+			pos:            0,
+			hasCycle:       true,
+			startIndex:     0,
+			length:         1,
+			expectedString: "[7 -> (back to 7)]",
+		},
+		{
+			name:           "Case 4",
+			nums:           []int{3, 2, 0, -4, 9, 11},
+			pos:            0,
+			hasCycle:       true,
+			startIndex:     0,
+			length:         6,
+			expectedString: "[3, 2, 0, -4, 9, 11 -> (back to 3)]",
+		},
+		{
+			name:           "Case 5",
+			nums:           []int{1, 1, 1, 1, 1},
+			pos:            4,
+			hasCycle:       true,
+			startIndex:     4,
+			length:         1,
+			expectedString: "[1, 1, 1, 1, 1 -> (back to 1)]",
+		},
+		{
+			name:           "Case 6",
+			nums:           []int{},
+			pos:            -1,
+			hasCycle:       false,
+			startIndex:     -1,
+			length:         0,
+			expectedString: "[]",
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := createCyclicLinkedList(tc.nums, tc.pos)
+
+		if got := ll.String(); got != tc.expectedString {
+			t.Errorf("String(%v, %v) = %v, expected %v", tc.nums, tc.pos, got, tc.expectedString)
+		}
+		if got := fast_and_slow_pointers.HasCycle(ll.Head); got != tc.hasCycle {
+			t.Errorf("HasCycle(%v, %v) = %v, expected %v", tc.nums, tc.pos, got, tc.hasCycle)
+		}
+		if got := fast_and_slow_pointers.CycleLength(ll.Head); got != tc.length {
+			t.Errorf("CycleLength(%v, %v) = %v, expected %v", tc.nums, tc.pos, got, tc.length)
+		}
+
+		var expectedStart *structs.LinkedListNode
+		for i, node := 0, ll.Head; tc.startIndex >= 0; i, node = i+1, node.Next {
+			if i == tc.startIndex {
+				expectedStart = node
+				break
+			}
+		}
+		if got := fast_and_slow_pointers.CycleStart(ll.Head); got != expectedStart {
+			t.Errorf("CycleStart(%v, %v) = %p, expected %p", tc.nums, tc.pos, got, expectedStart)
+		}
+
+		if got := fast_and_slow_pointers.BreakCycle(ll.Head); got != tc.hasCycle {
+			t.Errorf("BreakCycle(%v, %v) = %v, expected %v", tc.nums, tc.pos, got, tc.hasCycle)
+		}
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.nums)
+		if ll.String() != llExpected.String() || fast_and_slow_pointers.HasCycle(ll.Head) {
+			t.Errorf("BreakCycle(%v, %v) left %v, expected %v", tc.nums, tc.pos, ll.String(), llExpected.String())
+		}
+	}
+}
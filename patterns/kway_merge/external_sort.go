@@ -0,0 +1,306 @@
+package kway_merge
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultRunSize is the number of bytes of lines held in memory per run when ExternalSortOptions.RunSize is zero
+	DefaultRunSize = 16 << 20
+	// DefaultMaxFanIn is the number of runs merged at once when ExternalSortOptions.MaxFanIn is zero
+	DefaultMaxFanIn = 64
+)
+
+var (
+	// ErrInvalidRunSize is returned when ExternalSortOptions.RunSize is negative
+	ErrInvalidRunSize = errors.New("run size must not be negative")
+	// ErrInvalidFanIn is returned when ExternalSortOptions.MaxFanIn is negative or one, which could never reduce the runs
+	ErrInvalidFanIn = errors.New("fan-in must be at least 2")
+	// ErrSameFile is returned when ExternalSortFile is asked to write the sorted lines over its own input
+	ErrSameFile = errors.New("input and output are the same file")
+)
+
+// ExternalSortOptions configures ExternalSort. The zero value sorts whole lines with runs of DefaultRunSize bytes.
+type ExternalSortOptions struct {
+	// RunSize is the maximum number of bytes of lines sorted in memory at once, every run is written to its own temp file.
+	// A single line longer than RunSize forms a run on its own.
+	RunSize int
+	// Key extracts the sort key from a line, lines are sorted by the whole line if it is nil.
+	// Lines with equal keys keep their input order.
+	Key func(line string) string
+	// Dedup keeps only the first line of every key.
+	Dedup bool
+	// TempDir is the directory where the runs are written, the default directory for temporary files is used if it is empty.
+	TempDir string
+	// MaxFanIn is the maximum number of runs merged at once, which bounds the number of open files.
+	// When there are more runs, they are merged in several passes. DefaultMaxFanIn is used if it is zero.
+	MaxFanIn int
+}
+
+// record is a line together with its sort key, so the key is extracted only once per line
+type record struct {
+	key  string
+	line string
+}
+
+// ExternalSort sorts the newline-delimited lines of r which may not fit in memory and writes them to w,
+// every line is terminated by a newline in the output.
+// The lines are first split into sorted runs of at most options.RunSize bytes, each written to a temp file,
+// then the runs are merged with a min heap holding the current line of every run, like KSmallestNumber does.
+// At most options.MaxFanIn runs are merged at once, so with more runs consecutive groups of them are first merged
+// into longer runs until few enough are left. The temp files are removed before returning.
+// This solution has time complexity of O(n log n) and space complexity of O(RunSize + MaxFanIn) in memory and O(n) on disk,
+// where n is the number of lines.
+func ExternalSort(r io.Reader, w io.Writer, options ExternalSortOptions) error {
+	if options.RunSize < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidRunSize, options.RunSize)
+	}
+	if options.RunSize == 0 {
+		options.RunSize = DefaultRunSize
+	}
+	if options.MaxFanIn < 0 || options.MaxFanIn == 1 {
+		return fmt.Errorf("%w: %d", ErrInvalidFanIn, options.MaxFanIn)
+	}
+	if options.MaxFanIn == 0 {
+		options.MaxFanIn = DefaultMaxFanIn
+	}
+	if options.Key == nil {
+		options.Key = func(line string) string { return line }
+	}
+
+	dir, err := os.MkdirTemp(options.TempDir, "external-sort-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	runs, err := writeRuns(r, dir, options)
+	if err != nil {
+		return err
+	}
+	return mergeRuns(runs, dir, w, options)
+}
+
+// ExternalSortFile sorts the lines of the input file into the output file, see ExternalSort.
+// The output must be another file than the input. The lines are written into a temp file next to the output,
+// which replaces the output only once it is complete, so a failing sort leaves a previous output untouched.
+// A replaced output keeps its permissions, a new one is created with 0644.
+func ExternalSortFile(inputPath string, outputPath string, options ExternalSortOptions) error {
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	// comparing the files rather than the paths also catches links to the input
+	inputInfo, err := input.Stat()
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(0o644)
+	if outputInfo, err := os.Stat(outputPath); err == nil {
+		if os.SameFile(inputInfo, outputInfo) {
+			return fmt.Errorf("%w: %s", ErrSameFile, outputPath)
+		}
+		mode = outputInfo.Mode().Perm()
+	}
+
+	// the temp file is in the directory of the output, so renaming it doesn't copy the lines across file systems
+	output, err := os.CreateTemp(filepath.Dir(outputPath), filepath.Base(outputPath)+".tmp-")
+	if err != nil {
+		return err
+	}
+	// the temp file is gone after a successful rename, otherwise the partial output is removed
+	defer os.Remove(output.Name())
+
+	if err := output.Chmod(mode); err != nil {
+		output.Close()
+		return err
+	}
+	if err := ExternalSort(input, output, options); err != nil {
+		output.Close()
+		return err
+	}
+	if err := output.Close(); err != nil {
+		return err
+	}
+	return os.Rename(output.Name(), outputPath)
+}
+
+// writeRuns splits the lines of r into sorted runs and writes each of them into a file of dir, it returns the file paths in input order
+func writeRuns(r io.Reader, dir string, options ExternalSortOptions) ([]string, error) {
+	var runs []string
+	var records []record
+	size := 0
+
+	flush := func() error {
+		if len(records) == 0 {
+			return nil
+		}
+		// a stable sort keeps lines with equal keys in input order, which the merge preserves across runs
+		slices.SortStableFunc(records, compareRecords)
+		path := filepath.Join(dir, fmt.Sprintf("run-%06d", len(runs)))
+		if err := writeRun(path, records, options.Dedup); err != nil {
+			return err
+		}
+		runs = append(runs, path)
+		records, size = records[:0], 0
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			if size > 0 && size+len(line) > options.RunSize {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+			records = append(records, record{key: options.Key(line), line: line})
+			size += len(line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// writeRun writes the sorted records into a new file, only the first record of every key is written when dedup is set
+func writeRun(path string, records []record, dedup bool) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for i, rec := range records {
+		if dedup && i > 0 && records[i-1].key == rec.key {
+			continue
+		}
+		writer.WriteString(rec.line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// mergeRuns merges the sorted run files into w, at most options.MaxFanIn of them are open at once.
+// While there are more runs, every pass merges consecutive groups of runs into new run files of dir,
+// merging only consecutive runs keeps lines with equal keys in input order.
+func mergeRuns(runs []string, dir string, w io.Writer, options ExternalSortOptions) error {
+	for pass := 0; len(runs) > options.MaxFanIn; pass++ {
+		merged := make([]string, 0, (len(runs)+options.MaxFanIn-1)/options.MaxFanIn)
+		for start := 0; start < len(runs); start += options.MaxFanIn {
+			group := runs[start:min(start+options.MaxFanIn, len(runs))]
+			path := filepath.Join(dir, fmt.Sprintf("pass-%d-run-%06d", pass, len(merged)))
+			if err := mergeRunsIntoFile(group, path, options); err != nil {
+				return err
+			}
+			merged = append(merged, path)
+		}
+		runs = merged
+	}
+	return mergeGroup(runs, w, options)
+}
+
+// mergeRunsIntoFile merges the sorted run files into a new run file and removes them, so the disk holds every line only once
+func mergeRunsIntoFile(runs []string, path string, options ExternalSortOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := mergeGroup(runs, file, options); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if err := os.Remove(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeGroup merges the sorted run files into w with Merge, only the first line of every key is written when options.Dedup is set
+func mergeGroup(runs []string, w io.Writer, options ExternalSortOptions) error {
+	readers := make([]*runReader, len(runs))
+	sources := make([]iter.Seq[record], len(runs))
+	for i, path := range runs {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		readers[i] = &runReader{reader: bufio.NewReader(file), key: options.Key}
+		sources[i] = readers[i].records
+	}
+
+	writer := bufio.NewWriter(w)
+	var previous *record
+	for rec := range Merge(compareRecords, sources...) {
+		// equal keys are adjacent after merging, so only the first one of them is written
+		if options.Dedup && previous != nil && previous.key == rec.key {
+			continue
+		}
+		writer.WriteString(rec.line)
+		writer.WriteByte('\n')
+		previous = &rec
+	}
+
+	for _, reader := range readers {
+		if reader.err != nil {
+			return reader.err
+		}
+	}
+	return writer.Flush()
+}
+
+// runReader reads the records of a run file, the error which stopped reading is kept in err
+type runReader struct {
+	reader *bufio.Reader
+	key    func(line string) string
+	err    error
+}
+
+func (r *runReader) records(yield func(record) bool) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			return
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if !yield(record{key: r.key(line), line: line}) {
+			return
+		}
+	}
+}
+
+func compareRecords(a, b record) int {
+	return cmp.Compare(a.key, b.key)
+}
@@ -0,0 +1,292 @@
+package kway_merge_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/patterns/kway_merge"
+)
+
+// writeRandomFile writes lines of the form "<key>,<sequence>" until the file has at least size bytes and returns the lines
+func writeRandomFile(t *testing.T, path string, size int, keys int, rng *rand.Rand) []string {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	var lines []string
+	written := 0
+	for written < size {
+		line := fmt.Sprintf("key-%08d,%d", rng.Intn(keys), len(lines))
+		lines = append(lines, line)
+		written += len(line) + 1
+		writer.WriteString(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(content), "\n")
+	if lines[len(lines)-1] != "" {
+		t.Fatalf("%s doesn't end with a newline", path)
+	}
+	return lines[:len(lines)-1]
+}
+
+func keyOf(line string) string {
+	key, _, _ := strings.Cut(line, ",")
+	return key
+}
+
+func TestExternalSortFile(t *testing.T) {
+	dir := t.TempDir()
+	tempDir := filepath.Join(dir, "runs")
+	if err := os.Mkdir(tempDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	input := filepath.Join(dir, "input.txt")
+	lines := writeRandomFile(t, input, 4<<20, 50000, rand.New(rand.NewSource(15)))
+
+	tests := []struct {
+		name    string
+		options kway_merge.ExternalSortOptions
+		// expected builds the expected output from the input lines in memory
+		expected func(lines []string) []string
+	}{
+		{
+			name:    "Case 1",
+			options: kway_merge.ExternalSortOptions{RunSize: 256 << 10, TempDir: tempDir},
+			expected: func(lines []string) []string {
+				return slices.Sorted(slices.Values(lines))
+			},
+		},
+		{
+			name:    "Case 2",
+			options: kway_merge.ExternalSortOptions{RunSize: 100 << 10, Key: keyOf, TempDir: tempDir},
+			expected: func(lines []string) []string {
+				// sorting by key keeps the input order of equal keys
+				sorted := slices.Clone(lines)
+				slices.SortStableFunc(sorted, func(a, b string) int { return strings.Compare(keyOf(a), keyOf(b)) })
+				return sorted
+			},
+		},
+		{
+			name:    "Case 3",
+			options: kway_merge.ExternalSortOptions{RunSize: 300 << 10, Key: keyOf, Dedup: true, TempDir: tempDir},
+			expected: func(lines []string) []string {
+				// only the first line of every key in input order is kept
+				seen := make(map[string]bool)
+				var unique []string
+				for _, line := range lines {
+					if !seen[keyOf(line)] {
+						seen[keyOf(line)] = true
+						unique = append(unique, line)
+					}
+				}
+				slices.SortFunc(unique, func(a, b string) int { return strings.Compare(keyOf(a), keyOf(b)) })
+				return unique
+			},
+		},
+		{
+			name:    "Case 4",
+			options: kway_merge.ExternalSortOptions{TempDir: tempDir},
+			expected: func(lines []string) []string {
+				return slices.Sorted(slices.Values(lines))
+			},
+		},
+		{
+			name:    "Case 5",
+			options: kway_merge.ExternalSortOptions{RunSize: 50 << 10, Key: keyOf, MaxFanIn: 3, TempDir: tempDir},
+			expected: func(lines []string) []string {
+				// about 80 runs take several merge passes, which still keep the input order of equal keys
+				sorted := slices.Clone(lines)
+				slices.SortStableFunc(sorted, func(a, b string) int { return strings.Compare(keyOf(a), keyOf(b)) })
+				return sorted
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(dir, tt.name+".txt")
+			if err := kway_merge.ExternalSortFile(input, output, tt.options); err != nil {
+				t.Fatalf("ExternalSortFile() error = %v", err)
+			}
+			if got, want := readLines(t, output), tt.expected(lines); !slices.Equal(got, want) {
+				t.Errorf("ExternalSortFile() wrote %d lines, want %d sorted lines", len(got), len(want))
+			}
+
+			// the runs are removed once the file is sorted
+			entries, err := os.ReadDir(tempDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 0 {
+				t.Errorf("ExternalSortFile() left %d entries in the temp dir", len(entries))
+			}
+		})
+	}
+}
+
+func TestExternalSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		options  kway_merge.ExternalSortOptions
+		expected string
+	}{
+		{
+			name:     "Case 1",
+			input:    "c\na\nb",
+			options:  kway_merge.ExternalSortOptions{RunSize: 1},
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "Case 2",
+			input:    "b,1\na,2\nb,3\na,4\n",
+			options:  kway_merge.ExternalSortOptions{RunSize: 4, Key: keyOf, Dedup: true},
+			expected: "a,2\nb,1\n",
+		},
+		{
+			name:     "Case 3",
+			input:    "x\n\n\nw\n",
+			options:  kway_merge.ExternalSortOptions{RunSize: 2},
+			expected: "\n\nw\nx\n",
+		},
+		{
+			name:     "Case 4",
+			input:    "",
+			options:  kway_merge.ExternalSortOptions{},
+			expected: "",
+		},
+		{
+			name:     "Case 5",
+			input:    "d,1\nb,2\nd,3\na,4\nc,5\nb,6\na,7\n",
+			options:  kway_merge.ExternalSortOptions{RunSize: 1, Key: keyOf, Dedup: true, MaxFanIn: 2},
+			expected: "a,4\nb,2\nc,5\nd,1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output strings.Builder
+			if err := kway_merge.ExternalSort(strings.NewReader(tt.input), &output, tt.options); err != nil {
+				t.Fatalf("ExternalSort(%q) error = %v", tt.input, err)
+			}
+			if output.String() != tt.expected {
+				t.Errorf("ExternalSort(%q) = %q, want %q", tt.input, output.String(), tt.expected)
+			}
+		})
+	}
+
+	err := kway_merge.ExternalSort(strings.NewReader("a\n"), &strings.Builder{}, kway_merge.ExternalSortOptions{RunSize: -1})
+	if !errors.Is(err, kway_merge.ErrInvalidRunSize) {
+		t.Errorf("ExternalSort() with a negative run size error = %v, want %v", err, kway_merge.ErrInvalidRunSize)
+	}
+	err = kway_merge.ExternalSort(strings.NewReader("a\n"), &strings.Builder{}, kway_merge.ExternalSortOptions{MaxFanIn: 1})
+	if !errors.Is(err, kway_merge.ErrInvalidFanIn) {
+		t.Errorf("ExternalSort() with a fan-in of 1 error = %v, want %v", err, kway_merge.ErrInvalidFanIn)
+	}
+}
+
+func TestExternalSortFileSameFile(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(input, []byte("b\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.txt")
+	if err := os.Symlink(input, link); err != nil {
+		t.Fatal(err)
+	}
+
+	// the input is rejected however its path is spelled, before it is truncated
+	for _, output := range []string{input, filepath.Join(dir, ".", "input.txt"), link} {
+		if err := kway_merge.ExternalSortFile(input, output, kway_merge.ExternalSortOptions{}); !errors.Is(err, kway_merge.ErrSameFile) {
+			t.Errorf("ExternalSortFile(%s, %s) error = %v, want %v", input, output, err, kway_merge.ErrSameFile)
+		}
+	}
+	if got := readLines(t, input); !slices.Equal(got, []string{"b", "a"}) {
+		t.Errorf("ExternalSortFile() changed the input to %v", got)
+	}
+}
+
+func TestExternalSortFileKeepsOutputOnError(t *testing.T) {
+	dir := t.TempDir()
+	tempDir := filepath.Join(dir, "runs")
+	if err := os.Mkdir(tempDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	input := filepath.Join(dir, "input.txt")
+	output := filepath.Join(dir, "output.txt")
+	if err := os.WriteFile(input, []byte("d\nc\nb\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(output, []byte("previous\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the key is extracted once per input line while writing the runs, the next call comes from the merge,
+	// which then fails because the runs it has to remove after merging them are deleted under it
+	calls := 0
+	key := func(line string) string {
+		if calls++; calls == 5 {
+			entries, _ := os.ReadDir(tempDir)
+			for _, entry := range entries {
+				os.RemoveAll(filepath.Join(tempDir, entry.Name()))
+			}
+		}
+		return line
+	}
+	options := kway_merge.ExternalSortOptions{RunSize: 1, Key: key, MaxFanIn: 2, TempDir: tempDir}
+	if err := kway_merge.ExternalSortFile(input, output, options); err == nil {
+		t.Fatal("ExternalSortFile() error = nil, want the merge to fail")
+	}
+
+	// the previous output is untouched and the partial output is removed
+	if got := readLines(t, output); !slices.Equal(got, []string{"previous"}) {
+		t.Errorf("ExternalSortFile() changed the output to %v after failing", got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("ExternalSortFile() left %d entries next to the output, want 3", len(entries))
+	}
+
+	// a successful sort replaces the output and keeps its permissions
+	options.Key = nil
+	if err := kway_merge.ExternalSortFile(input, output, options); err != nil {
+		t.Fatalf("ExternalSortFile() error = %v", err)
+	}
+	if got := readLines(t, output); !slices.Equal(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("ExternalSortFile() wrote %v, want the sorted lines", got)
+	}
+	info, err := os.Stat(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("ExternalSortFile() output mode = %v, want %v", info.Mode().Perm(), os.FileMode(0o600))
+	}
+}
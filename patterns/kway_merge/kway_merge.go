@@ -1,7 +1,13 @@
 package kway_merge
 
 import (
-	"container/heap"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+
+	"github.com/adyanf/coding-patterns-dsa/patterns/modified_binary_search"
+	"github.com/adyanf/coding-patterns-dsa/structs"
 )
 
 // The K-way merge pattern is an essential algorithmic strategy for merging K sorted data structures, such as arrays and linked lists, into a single sorted data structure.
@@ -27,15 +33,21 @@ import (
 // - Involves merging sorted arrays or a matrix: The problem involves a collection of sorted arrays or a matrix with rows or columns sorted in a specific order that needs to be merged. This could be the core of the problem or a step toward the solution.
 // - Seeking the k-th smallest/largest across sorted collections: The problem involves identifying the k-th smallest or largest element across multiple sorted arrays or linked lists.
 
+var (
+	// ErrEmptyMatrix is returned when a matrix has no rows or its rows have no elements
+	ErrEmptyMatrix = errors.New("matrix is empty")
+	// ErrInvalidK is returned when k is not between 1 and the number of elements of the matrix
+	ErrInvalidK = errors.New("invalid k")
+)
+
 // FindKSmallestPairs finds the k smallest pairs from given array list1 and list2
 func FindKSmallestPairs(list1 []int, list2 []int, k int) [][]int {
 	// init the min sum heap to help getting the minimum sum for each iteration
-	var minSumHeap MinSumHeap
-	heap.Init(&minSumHeap)
+	minSumHeap := NewMinSumHeap()
 
 	// push the sum of every element of list1 with the first element of list2
 	for i := 0; i < len(list1); i++ {
-		heap.Push(&minSumHeap, Sum{sum: list1[i] + list2[0], left: i, right: 0})
+		minSumHeap.Push(Sum{sum: list1[i] + list2[0], left: i, right: 0})
 	}
 
 	// init result and counter
@@ -45,7 +57,7 @@ func FindKSmallestPairs(list1 []int, list2 []int, k int) [][]int {
 	// keep the for loop as long as the heap is not empty and the counter still smaller than k
 	for !minSumHeap.Empty() && counter < k {
 		// pop the smallest sum from minSumHeap
-		smallest := heap.Pop(&minSumHeap).(Sum)
+		smallest := minSumHeap.Pop()
 		left, right := smallest.left, smallest.right
 
 		// add the smallest pair to result and add the counter
@@ -55,22 +67,27 @@ func FindKSmallestPairs(list1 []int, list2 []int, k int) [][]int {
 		// for the popped pair, push the sum of left elementh of list1 and right+1 elementh of list2, if right+1 still less than length list2
 		nextRight := right + 1
 		if nextRight < len(list2) {
-			heap.Push(&minSumHeap, Sum{sum: list1[left] + list2[nextRight], left: left, right: nextRight})
+			minSumHeap.Push(Sum{sum: list1[left] + list2[nextRight], left: left, right: nextRight})
 		}
 	}
 
 	return result
 }
 
-// KthSmallestElement returns the k-th smallest element from a matrix
-func KthSmallestElement(matrix [][]int, k int) int {
+// KthSmallestElement returns the k-th smallest element from a matrix whose rows and columns are sorted.
+// It returns an error if the matrix is empty or k is out of range.
+// This solution has time complexity of O(k log n) and space complexity of O(n), where n is the number of rows.
+func KthSmallestElement(matrix [][]int, k int) (int, error) {
+	if err := validateMatrix(matrix, k); err != nil {
+		return 0, err
+	}
+
 	// init the cell min heap to help getting the minimum cell for each iteration
-	var cellMinHeap CellMinHeap
-	heap.Init(&cellMinHeap)
+	cellMinHeap := NewCellMinHeap()
 
 	// push the value of matrix for each row with column 0
 	for i := 0; i < len(matrix); i++ {
-		heap.Push(&cellMinHeap, Cell{value: matrix[i][0], row: i, column: 0})
+		cellMinHeap.Push(Cell{value: matrix[i][0], row: i, column: 0})
 	}
 
 	// init the smallest element and counter
@@ -78,7 +95,7 @@ func KthSmallestElement(matrix [][]int, k int) int {
 	counter := 0
 	for {
 		// pop the smallest cell from the heap
-		smallestCell := heap.Pop(&cellMinHeap).(Cell)
+		smallestCell := cellMinHeap.Pop()
 		rowIndex, columnIndex := smallestCell.row, smallestCell.column
 
 		// add counter to mark the popped element position after sorted
@@ -87,7 +104,7 @@ func KthSmallestElement(matrix [][]int, k int) int {
 		// for the popped cell, push the value of matrix with row rowIndex and column nextColumn [columnIndex + 1]
 		nextColumn := columnIndex + 1
 		if nextColumn < len(matrix[0]) {
-			heap.Push(&cellMinHeap, Cell{value: matrix[rowIndex][nextColumn], row: rowIndex, column: nextColumn})
+			cellMinHeap.Push(Cell{value: matrix[rowIndex][nextColumn], row: rowIndex, column: nextColumn})
 		}
 
 		// if counter equal k then set the smallest element and break the for loop
@@ -97,19 +114,61 @@ func KthSmallestElement(matrix [][]int, k int) int {
 		}
 	}
 
-	return smallestElement
+	return smallestElement, nil
+}
+
+// KthSmallestElementBinarySearch returns the k-th smallest element from a matrix whose rows and columns are sorted,
+// like KthSmallestElement, but binary searches on the value range of the matrix instead of merging its rows.
+// The answer is the smallest value x that has at least k elements less than or equal to it,
+// the elements less than or equal to x are counted by walking the staircase from the bottom left corner.
+// It returns an error if the matrix is empty or k is out of range.
+// This solution has time complexity of O((n + m) log(max - min)) and space complexity of O(1),
+// where n is the number of rows and m is the number of columns.
+func KthSmallestElementBinarySearch(matrix [][]int, k int) (int, error) {
+	if err := validateMatrix(matrix, k); err != nil {
+		return 0, err
+	}
+
+	rows, columns := len(matrix), len(matrix[0])
+	countLessOrEqual := func(x int) int {
+		// starting at the bottom left, every column has its elements <= x above the current row
+		count, row := 0, rows-1
+		for column := 0; column < columns; column++ {
+			for row >= 0 && matrix[row][column] > x {
+				row--
+			}
+			count += row + 1
+		}
+		return count
+	}
+
+	// the answer is always found, because k elements are less than or equal to the maximum
+	answer, _ := modified_binary_search.SearchAnswer(matrix[0][0], matrix[rows-1][columns-1], func(x int) bool {
+		return countLessOrEqual(x) >= k
+	})
+	return answer, nil
+}
+
+// validateMatrix checks that the matrix has elements and k is between 1 and the number of elements
+func validateMatrix(matrix [][]int, k int) error {
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return ErrEmptyMatrix
+	}
+	if count := len(matrix) * len(matrix[0]); k < 1 || k > count {
+		return fmt.Errorf("%w: %d is not between 1 and %d", ErrInvalidK, k, count)
+	}
+	return nil
 }
 
 // KSmallestNumber returns the k-th smallest number from lists
 func KSmallestNumber(lists [][]int, k int) int {
 	// init the list min heap to help getting the minimum list element for each iteration
-	var listMinHeap ListMinHeap
-	heap.Init(&listMinHeap)
+	listMinHeap := NewListMinHeap()
 
 	// push the value of the first index of each list together with its list index and element index
 	for i := 0; i < len(lists); i++ {
 		if len(lists[i]) > 0 {
-			heap.Push(&listMinHeap, ListElement{listIndex: i, elementIndex: 0, value: lists[i][0]})
+			listMinHeap.Push(ListElement{listIndex: i, elementIndex: 0, value: lists[i][0]})
 		}
 	}
 
@@ -119,7 +178,7 @@ func KSmallestNumber(lists [][]int, k int) int {
 	// iterating as long as the list min heap not empty and counter less than k
 	for !listMinHeap.Empty() && counter < k {
 		// pop the smallest list element
-		smallestListElement := heap.Pop(&listMinHeap).(ListElement)
+		smallestListElement := listMinHeap.Pop()
 		listIndex, elementIndex := smallestListElement.listIndex, smallestListElement.elementIndex
 
 		// set the smallest number and add counter
@@ -128,7 +187,7 @@ func KSmallestNumber(lists [][]int, k int) int {
 
 		// if the smallest list element's list still has next element, push it to the list min heap
 		if elementIndex+1 < len(lists[listIndex]) {
-			heap.Push(&listMinHeap, ListElement{listIndex: listIndex, elementIndex: elementIndex + 1, value: lists[listIndex][elementIndex+1]})
+			listMinHeap.Push(ListElement{listIndex: listIndex, elementIndex: elementIndex + 1, value: lists[listIndex][elementIndex+1]})
 		}
 	}
 
@@ -136,149 +195,283 @@ func KSmallestNumber(lists [][]int, k int) int {
 	return smallestNumber
 }
 
-// struct Sum initialization
-type Sum struct {
-	sum   int
-	left  int
-	right int
-}
+// SmallestRangeCoveringKLists returns the smallest range [lo, hi] which includes at least one number from each of the sorted lists.
+// A range is smaller than another if it is narrower, or equally wide and starting at a smaller number.
+// Keeps the current element of every list in a min heap together with the greatest of them, so the heap top and
+// the greatest element always form a range covering every list. The range is narrowed by moving the list at the top forward.
+// It returns 0, 0 if there are no lists or one of them is empty.
+// This solution has time complexity of O(n log k) and space complexity of O(k),
+// where n is the total number of elements and k is the number of lists.
+func SmallestRangeCoveringKLists(lists [][]int) (lo, hi int) {
+	if len(lists) == 0 {
+		return 0, 0
+	}
 
-// struct MinSumHeap initialization
-type MinSumHeap []Sum
+	// push the first element of every list and remember the greatest of them
+	listMinHeap := NewListMinHeap()
+	currentMax := math.MinInt
+	for i, list := range lists {
+		if len(list) == 0 {
+			return 0, 0
+		}
+		listMinHeap.Push(ListElement{value: list[0], listIndex: i, elementIndex: 0})
+		currentMax = max(currentMax, list[0])
+	}
 
-// Len returns the length of the heap
-func (h MinSumHeap) Len() int {
-	return len(h)
-}
+	top, _ := listMinHeap.Peek()
+	lo, hi = top.value, currentMax
+	for {
+		// the smallest current element and the greatest one cover every list
+		smallest := listMinHeap.Pop()
+		if rangeWidth(smallest.value, currentMax) < rangeWidth(lo, hi) {
+			lo, hi = smallest.value, currentMax
+		}
 
-// Empty returns true if the heap is empty
-func (h MinSumHeap) Empty() bool {
-	return len(h) == 0
+		// the range can't be narrowed anymore once a list runs out of elements
+		nextIndex := smallest.elementIndex + 1
+		if nextIndex == len(lists[smallest.listIndex]) {
+			return lo, hi
+		}
+		next := lists[smallest.listIndex][nextIndex]
+		listMinHeap.Push(ListElement{value: next, listIndex: smallest.listIndex, elementIndex: nextIndex})
+		currentMax = max(currentMax, next)
+	}
 }
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h MinSumHeap) Less(i, j int) bool {
-	return h[i].sum < h[j].sum || (h[i].sum == h[j].sum && h[i].left < h[j].left)
+// rangeWidth returns hi - lo for lo <= hi, as an uint64 the width of any range of ints fits without overflowing
+func rangeWidth(lo, hi int) uint64 {
+	return uint64(hi) - uint64(lo)
 }
 
-// Swap swaps the elements with indexes i and j
-func (h MinSumHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
-}
+// MedianOfTwoSortedArrays returns the median of the numbers of both sorted arrays, or 0 if both are empty.
+// Merges both arrays with a min heap, like KSmallestNumber does, until the middle of the merged array is reached.
+// This solution has time complexity of O(n + m) and space complexity of O(1).
+func MedianOfTwoSortedArrays(nums1 []int, nums2 []int) float64 {
+	total := len(nums1) + len(nums2)
+	if total == 0 {
+		return 0
+	}
 
-// Top show the fastest end time
-func (h MinSumHeap) Top() interface{} {
-	return h[0]
-}
+	lists := [][]int{nums1, nums2}
+	listMinHeap := NewListMinHeap()
+	for i, list := range lists {
+		if len(list) > 0 {
+			listMinHeap.Push(ListElement{value: list[0], listIndex: i, elementIndex: 0})
+		}
+	}
 
-// Push pushes an element into the MinSumHeap
-func (h *MinSumHeap) Push(x interface{}) {
-	*h = append(*h, x.(Sum))
-}
+	// pop the merged elements up to the middle one, keeping the one before it for an even total
+	var previous, current int
+	for counter := 0; counter <= total/2; counter++ {
+		smallest := listMinHeap.Pop()
+		previous, current = current, smallest.value
 
-// Pop pops the element at the top of the MinSumHeap
-func (h *MinSumHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
-}
+		if nextIndex := smallest.elementIndex + 1; nextIndex < len(lists[smallest.listIndex]) {
+			listMinHeap.Push(ListElement{value: lists[smallest.listIndex][nextIndex], listIndex: smallest.listIndex, elementIndex: nextIndex})
+		}
+	}
 
-// struct Cell initialization
-type Cell struct {
-	value  int
-	row    int
-	column int
+	if total%2 == 0 {
+		// converting before adding keeps the sum of two large numbers from overflowing
+		return (float64(previous) + float64(current)) / 2
+	}
+	return float64(current)
 }
 
-// struct CellMinHeap initialization
-type CellMinHeap []Cell
+// KthSmallestPrimeFraction returns the k-th smallest fraction arr[i] / arr[j] with i < j as the pair [arr[i], arr[j]],
+// where arr is sorted and contains 1 and prime numbers. It returns nil if k is out of range.
+// Every denominator forms a sorted list of fractions over the growing numerators, so the lists are merged with a min heap of cells
+// where the row is the numerator index and the column is the denominator index.
+// This solution has time complexity of O(k log n) and space complexity of O(n).
+func KthSmallestPrimeFraction(arr []int, k int) []int {
+	n := len(arr)
+	if k < 1 || k > n*(n-1)/2 {
+		return nil
+	}
 
-// Len returns the length of the heap
-func (h CellMinHeap) Len() int {
-	return len(h)
-}
+	// a/b < c/d is compared as a*d < c*b to avoid floating point errors
+	fractionMinHeap := structs.NewHeap(func(a, b Cell) bool {
+		return arr[a.row]*arr[b.column] < arr[b.row]*arr[a.column]
+	})
+	for j := 1; j < n; j++ {
+		fractionMinHeap.Push(Cell{row: 0, column: j})
+	}
 
-// Empty returns true if the heap is empty
-func (h CellMinHeap) Empty() bool {
-	return len(h) == 0
-}
+	for counter := 1; ; counter++ {
+		smallest := fractionMinHeap.Pop()
+		if counter == k {
+			return []int{arr[smallest.row], arr[smallest.column]}
+		}
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h CellMinHeap) Less(i, j int) bool {
-	return h[i].value < h[j].value
+		// the next fraction with the same denominator has the next numerator
+		if nextRow := smallest.row + 1; nextRow < smallest.column {
+			fractionMinHeap.Push(Cell{row: nextRow, column: smallest.column})
+		}
+	}
 }
 
-// Swap swaps the elements with indexes i and j
-func (h CellMinHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
-}
+// KthSmallestSumOfMatrixRows returns the k-th smallest sum of an array formed by choosing exactly one element from each sorted row of the matrix.
+// It returns -1 if the matrix is empty or k is out of range.
+// Keeps the k smallest sums of the rows seen so far and merges them with the next row like FindKSmallestPairs does.
+// This solution has time complexity of O(m * k log k) and space complexity of O(k), where m is the number of rows.
+func KthSmallestSumOfMatrixRows(matrix [][]int, k int) int {
+	if len(matrix) == 0 || k < 1 {
+		return -1
+	}
 
-// Top show the fastest end time
-func (h CellMinHeap) Top() interface{} {
-	return h[0]
-}
+	sums := []int{0}
+	for _, row := range matrix {
+		sums = kSmallestSums(sums, row, k)
+	}
 
-// Push pushes an element into the CellMinHeap
-func (h *CellMinHeap) Push(x interface{}) {
-	*h = append(*h, x.(Cell))
+	if len(sums) < k {
+		return -1
+	}
+	return sums[k-1]
 }
 
-// Pop pops the element at the top of the CellMinHeap
-func (h *CellMinHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// kSmallestSums returns the k smallest sums of an element of sums and an element of row, both sorted, in ascending order
+func kSmallestSums(sums []int, row []int, k int) []int {
+	if len(row) == 0 {
+		return nil
+	}
+
+	// push the sum of every element of sums with the first element of row
+	minSumHeap := NewMinSumHeap()
+	for i := 0; i < len(sums) && i < k; i++ {
+		minSumHeap.Push(Sum{sum: sums[i] + row[0], left: i, right: 0})
+	}
+
+	var result []int
+	for !minSumHeap.Empty() && len(result) < k {
+		smallest := minSumHeap.Pop()
+		result = append(result, smallest.sum)
+
+		if nextRight := smallest.right + 1; nextRight < len(row) {
+			minSumHeap.Push(Sum{sum: sums[smallest.left] + row[nextRight], left: smallest.left, right: nextRight})
+		}
+	}
+	return result
 }
 
-// struct ListElement initialization
-type ListElement struct {
-	value        int
-	listIndex    int
-	elementIndex int
+// Merge lazily merges the sorted sources into one sorted sequence, ordered by cmp.
+// Only the current element of every source is held in a min heap, so sources that don't fit in memory can be merged.
+// Equal elements are yielded in the order of their sources, so the merge is stable.
+// Every source is pulled at most once per yielded element and all sources are stopped when the iteration ends,
+// even if the consumer stops early.
+// This solution has time complexity of O(n log k) and space complexity of O(k),
+// where n is the number of yielded elements and k is the number of sources.
+func Merge[T any](cmp func(a, b T) int, sources ...iter.Seq[T]) iter.Seq[T] {
+	type sourceElement struct {
+		value       T
+		sourceIndex int
+	}
+
+	return func(yield func(T) bool) {
+		// pull from every source on demand and make sure all of them are stopped at the end
+		nexts := make([]func() (T, bool), len(sources))
+		for i, source := range sources {
+			next, stop := iter.Pull(source)
+			defer stop()
+			nexts[i] = next
+		}
+
+		// push the first element of every source into the min heap, ties are broken by the smaller source index
+		minHeap := structs.NewHeap(func(a, b sourceElement) bool {
+			c := cmp(a.value, b.value)
+			return c < 0 || (c == 0 && a.sourceIndex < b.sourceIndex)
+		})
+		for i, next := range nexts {
+			if value, ok := next(); ok {
+				minHeap.Push(sourceElement{value: value, sourceIndex: i})
+			}
+		}
+
+		for !minHeap.Empty() {
+			// pop and yield the smallest element
+			smallest := minHeap.Pop()
+			if !yield(smallest.value) {
+				return
+			}
+
+			// replace it with the next element of the same source
+			if value, ok := nexts[smallest.sourceIndex](); ok {
+				minHeap.Push(sourceElement{value: value, sourceIndex: smallest.sourceIndex})
+			}
+		}
+	}
 }
 
-// struct ListMinHeap initialization
-type ListMinHeap []ListElement
+// MergeLinkedLists merges the sorted linked lists into one sorted linked list and returns its head.
+// The nodes of the given lists are relinked, no new node is allocated.
+// This solution has time complexity of O(n log k) and space complexity of O(k),
+// where n is the total number of nodes and k is the number of lists.
+func MergeLinkedLists(lists ...*structs.LinkedListNode) *structs.LinkedListNode {
+	// push the head of every list into the min heap
+	minHeap := structs.NewHeap(func(a, b *structs.LinkedListNode) bool {
+		return a.Data < b.Data
+	})
+	for _, head := range lists {
+		if head != nil {
+			minHeap.Push(head)
+		}
+	}
+
+	// use a dummy node so appending the first node is no special case
+	dummy := &structs.LinkedListNode{}
+	tail := dummy
+	for !minHeap.Empty() {
+		// append the smallest node and push its successor in its place
+		smallest := minHeap.Pop()
+		if smallest.Next != nil {
+			minHeap.Push(smallest.Next)
+		}
+		tail.Next = smallest
+		tail = smallest
+	}
+	tail.Next = nil
 
-// Len returns the length of the heap
-func (h ListMinHeap) Len() int {
-	return len(h)
+	return dummy.Next
 }
 
-// Empty returns true if the heap is empty
-func (h ListMinHeap) Empty() bool {
-	return len(h) == 0
+// struct Sum initialization
+type Sum struct {
+	sum   int
+	left  int
+	right int
 }
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h ListMinHeap) Less(i, j int) bool {
-	return h[i].value < h[j].value || (h[i].value == h[j].value && h[i].listIndex < h[j].listIndex)
+// NewMinSumHeap returns a heap with the smallest sum at the top, ties are broken by the smaller left index
+func NewMinSumHeap() *structs.Heap[Sum] {
+	return structs.NewHeap(func(a, b Sum) bool {
+		return a.sum < b.sum || (a.sum == b.sum && a.left < b.left)
+	})
 }
 
-// Swap swaps the elements with indexes i and j
-func (h ListMinHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+// struct Cell initialization
+type Cell struct {
+	value  int
+	row    int
+	column int
 }
 
-// Top show the fastest end time
-func (h ListMinHeap) Top() interface{} {
-	return h[0]
+// NewCellMinHeap returns a heap with the smallest cell value at the top
+func NewCellMinHeap() *structs.Heap[Cell] {
+	return structs.NewHeap(func(a, b Cell) bool {
+		return a.value < b.value
+	})
 }
 
-// Push pushes an element into the ListMinHeap
-func (h *ListMinHeap) Push(x interface{}) {
-	*h = append(*h, x.(ListElement))
+// struct ListElement initialization
+type ListElement struct {
+	value        int
+	listIndex    int
+	elementIndex int
 }
 
-// Pop pops the element at the top of the ListMinHeap
-func (h *ListMinHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// NewListMinHeap returns a heap with the smallest list element at the top, ties are broken by the smaller list index
+func NewListMinHeap() *structs.Heap[ListElement] {
+	return structs.NewHeap(func(a, b ListElement) bool {
+		return a.value < b.value || (a.value == b.value && a.listIndex < b.listIndex)
+	})
 }
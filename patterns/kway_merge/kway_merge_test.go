@@ -1,9 +1,16 @@
 package kway_merge_test
 
 import (
+	"cmp"
+	"errors"
+	"iter"
+	"math"
+	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/adyanf/coding-patterns-dsa/patterns/kway_merge"
+	"github.com/adyanf/coding-patterns-dsa/structs"
 )
 
 func TestFindKSmallestPairs(t *testing.T) {
@@ -114,14 +121,94 @@ func TestKthSmallestElement(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := kway_merge.KthSmallestElement(test.matrix, test.k)
-			if got != test.expected {
-				t.Errorf("KthSmallestElement(%v, %d) = %d, want %d", test.matrix, test.k, got, test.expected)
+			got, err := kway_merge.KthSmallestElement(test.matrix, test.k)
+			if err != nil || got != test.expected {
+				t.Errorf("KthSmallestElement(%v, %d) = %d, %v, want %d", test.matrix, test.k, got, err, test.expected)
+			}
+			got, err = kway_merge.KthSmallestElementBinarySearch(test.matrix, test.k)
+			if err != nil || got != test.expected {
+				t.Errorf("KthSmallestElementBinarySearch(%v, %d) = %d, %v, want %d", test.matrix, test.k, got, err, test.expected)
 			}
 		})
 	}
 }
 
+func TestKthSmallestElementErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		matrix   [][]int
+		k        int
+		expected error
+	}{
+		{
+			name:     "Case 1",
+			matrix:   [][]int{},
+			k:        1,
+			expected: kway_merge.ErrEmptyMatrix,
+		},
+		{
+			name:     "Case 2",
+			matrix:   [][]int{{}},
+			k:        1,
+			expected: kway_merge.ErrEmptyMatrix,
+		},
+		{
+			name:     "Case 3",
+			matrix:   [][]int{{1, 2}, {3, 4}},
+			k:        5,
+			expected: kway_merge.ErrInvalidK,
+		},
+		{
+			name:     "Case 4",
+			matrix:   [][]int{{1, 2}, {3, 4}},
+			k:        0,
+			expected: kway_merge.ErrInvalidK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kway_merge.KthSmallestElement(test.matrix, test.k); !errors.Is(err, test.expected) {
+				t.Errorf("KthSmallestElement(%v, %d) error = %v, want %v", test.matrix, test.k, err, test.expected)
+			}
+			if _, err := kway_merge.KthSmallestElementBinarySearch(test.matrix, test.k); !errors.Is(err, test.expected) {
+				t.Errorf("KthSmallestElementBinarySearch(%v, %d) error = %v, want %v", test.matrix, test.k, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestKthSmallestElementBinarySearchMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+
+	for round := 0; round < 300; round++ {
+		// every element is at least its upper and left neighbours, so rows and columns are sorted
+		rows, columns := 1+rng.Intn(6), 1+rng.Intn(6)
+		matrix := make([][]int, rows)
+		var elements []int
+		for i := range matrix {
+			matrix[i] = make([]int, columns)
+			for j := range matrix[i] {
+				matrix[i][j] = rng.Intn(5) - 10
+				if i > 0 {
+					matrix[i][j] = max(matrix[i][j], matrix[i-1][j]+rng.Intn(3))
+				}
+				if j > 0 {
+					matrix[i][j] = max(matrix[i][j], matrix[i][j-1]+rng.Intn(3))
+				}
+				elements = append(elements, matrix[i][j])
+			}
+		}
+		slices.Sort(elements)
+
+		for k := 1; k <= len(elements); k++ {
+			if got, err := kway_merge.KthSmallestElementBinarySearch(matrix, k); err != nil || got != elements[k-1] {
+				t.Fatalf("KthSmallestElementBinarySearch(%v, %d) = %d, %v, want %d", matrix, k, got, err, elements[k-1])
+			}
+		}
+	}
+}
+
 func TestKSmallestNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -170,3 +257,503 @@ func TestKSmallestNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		lists    [][]int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			lists:    [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}},
+			expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "Case 2",
+			lists:    [][]int{{1, 1, 3}, {}, {1, 2}},
+			expected: []int{1, 1, 1, 2, 3},
+		},
+		{
+			name:     "Case 3",
+			lists:    [][]int{{5}},
+			expected: []int{5},
+		},
+		{
+			name:     "Case 4",
+			lists:    [][]int{{}, {}},
+			expected: []int{},
+		},
+		{
+			name:     "Case 5",
+			lists:    [][]int{},
+			expected: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := make([]iter.Seq[int], len(tt.lists))
+			for i, list := range tt.lists {
+				sources[i] = slices.Values(list)
+			}
+			result := slices.AppendSeq([]int{}, kway_merge.Merge(cmp.Compare[int], sources...))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Merge(%v) = %v, want %v", tt.lists, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeIsStable(t *testing.T) {
+	type record struct {
+		key    int
+		source string
+	}
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	first := slices.Values([]record{{1, "a"}, {2, "a"}, {2, "a"}})
+	second := slices.Values([]record{{1, "b"}, {2, "b"}})
+	result := slices.Collect(kway_merge.Merge(byKey, first, second))
+	expected := []record{{1, "a"}, {1, "b"}, {2, "a"}, {2, "a"}, {2, "b"}}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Merge = %v, want %v", result, expected)
+	}
+}
+
+func TestMergeStopsSourcesEarly(t *testing.T) {
+	// endless sources which record how far they were consumed and whether they were stopped
+	pulled := make([]int, 3)
+	stopped := make([]bool, 3)
+	sources := make([]iter.Seq[int], 3)
+	for i := range sources {
+		sources[i] = func(yield func(int) bool) {
+			defer func() { stopped[i] = true }()
+			for v := i; ; v += 3 {
+				pulled[i]++
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+
+	var result []int
+	for v := range kway_merge.Merge(cmp.Compare[int], sources...) {
+		if len(result) == 10 {
+			break
+		}
+		result = append(result, v)
+	}
+
+	if !slices.Equal(result, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Merge = %v, want the first 10 numbers", result)
+	}
+	for i := range sources {
+		if !stopped[i] {
+			t.Errorf("source %d was not stopped", i)
+		}
+		// the merge holds one element of every source ahead of what it yielded
+		if pulled[i] > 5 {
+			t.Errorf("source %d was pulled %d times, want at most 5", i, pulled[i])
+		}
+	}
+}
+
+func TestMergeMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(14))
+
+	for round := 0; round < 200; round++ {
+		lists := make([][]int, rng.Intn(8))
+		var expected []int
+		for i := range lists {
+			lists[i] = make([]int, rng.Intn(20))
+			for j := range lists[i] {
+				lists[i][j] = rng.Intn(50)
+			}
+			slices.Sort(lists[i])
+			expected = append(expected, lists[i]...)
+		}
+		slices.Sort(expected)
+
+		sources := make([]iter.Seq[int], len(lists))
+		for i, list := range lists {
+			sources[i] = slices.Values(list)
+		}
+		result := slices.Collect(kway_merge.Merge(cmp.Compare[int], sources...))
+		if !slices.Equal(result, expected) {
+			t.Fatalf("Merge(%v) = %v, want %v", lists, result, expected)
+		}
+	}
+}
+
+func TestMergeLinkedLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		lists    [][]int
+		expected string
+	}{
+		{
+			name:     "Case 1",
+			lists:    [][]int{{1, 4, 5}, {1, 3, 4}, {2, 6}},
+			expected: "[1, 1, 2, 3, 4, 4, 5, 6]",
+		},
+		{
+			name:     "Case 2",
+			lists:    [][]int{{}, {7}},
+			expected: "[7]",
+		},
+		{
+			name:     "Case 3",
+			lists:    [][]int{{2, 9}, {11}, {-3, 10, 12}},
+			expected: "[-3, 2, 9, 10, 11, 12]",
+		},
+		{
+			name:     "Case 4",
+			lists:    [][]int{},
+			expected: "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heads := make([]*structs.LinkedListNode, len(tt.lists))
+			for i, list := range tt.lists {
+				linkedList := new(structs.LinkedList)
+				linkedList.CreateLinkedList(list)
+				heads[i] = linkedList.Head
+			}
+			result := &structs.LinkedList{Head: kway_merge.MergeLinkedLists(heads...)}
+			if got := result.String(); got != tt.expected {
+				t.Errorf("MergeLinkedLists(%v) = %v, want %v", tt.lists, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSmallestRangeCoveringKLists(t *testing.T) {
+	tests := []struct {
+		name       string
+		lists      [][]int
+		expectedLo int
+		expectedHi int
+	}{
+		{
+			name:       "Case 1",
+			lists:      [][]int{{4, 10, 15, 24, 26}, {0, 9, 12, 20}, {5, 18, 22, 30}},
+			expectedLo: 20,
+			expectedHi: 24,
+		},
+		{
+			name:       "Case 2",
+			lists:      [][]int{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}},
+			expectedLo: 1,
+			expectedHi: 1,
+		},
+		{
+			name:       "Case 3",
+			lists:      [][]int{{1, 5}, {3, 7}},
+			expectedLo: 1,
+			expectedHi: 3,
+		},
+		{
+			name:       "Case 4",
+			lists:      [][]int{{1, 2}, {}},
+			expectedLo: 0,
+			expectedHi: 0,
+		},
+		{
+			name:       "Case 5",
+			lists:      [][]int{{math.MinInt, 0}, {math.MaxInt}},
+			expectedLo: 0,
+			expectedHi: math.MaxInt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := kway_merge.SmallestRangeCoveringKLists(tt.lists)
+			if lo != tt.expectedLo || hi != tt.expectedHi {
+				t.Errorf("SmallestRangeCoveringKLists(%v) = %d, %d, want %d, %d", tt.lists, lo, hi, tt.expectedLo, tt.expectedHi)
+			}
+		})
+	}
+}
+
+func randomSortedLists(rng *rand.Rand, count, maxLength, maxValue int) [][]int {
+	lists := make([][]int, count)
+	for i := range lists {
+		lists[i] = make([]int, 1+rng.Intn(maxLength))
+		for j := range lists[i] {
+			lists[i][j] = rng.Intn(maxValue) - maxValue/2
+		}
+		slices.Sort(lists[i])
+	}
+	return lists
+}
+
+func TestSmallestRangeCoveringKListsMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+
+	for round := 0; round < 500; round++ {
+		lists := randomSortedLists(rng, 1+rng.Intn(5), 6, 40)
+
+		// try every pair of numbers as the range, ordered by width and then by start
+		var values []int
+		for _, list := range lists {
+			values = append(values, list...)
+		}
+		expectedLo, expectedHi := 0, -1
+		for _, lo := range values {
+			for _, hi := range values {
+				if hi < lo || (expectedHi >= expectedLo && (hi-lo > expectedHi-expectedLo || (hi-lo == expectedHi-expectedLo && lo >= expectedLo))) {
+					continue
+				}
+				covered := true
+				for _, list := range lists {
+					if !slices.ContainsFunc(list, func(v int) bool { return lo <= v && v <= hi }) {
+						covered = false
+						break
+					}
+				}
+				if covered {
+					expectedLo, expectedHi = lo, hi
+				}
+			}
+		}
+
+		if lo, hi := kway_merge.SmallestRangeCoveringKLists(lists); lo != expectedLo || hi != expectedHi {
+			t.Fatalf("SmallestRangeCoveringKLists(%v) = %d, %d, want %d, %d", lists, lo, hi, expectedLo, expectedHi)
+		}
+	}
+}
+
+func TestMedianOfTwoSortedArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		nums1    []int
+		nums2    []int
+		expected float64
+	}{
+		{
+			name:     "Case 1",
+			nums1:    []int{1, 3},
+			nums2:    []int{2},
+			expected: 2,
+		},
+		{
+			name:     "Case 2",
+			nums1:    []int{1, 2},
+			nums2:    []int{3, 4},
+			expected: 2.5,
+		},
+		{
+			name:     "Case 3",
+			nums1:    []int{},
+			nums2:    []int{-4, 7},
+			expected: 1.5,
+		},
+		{
+			name:     "Case 4",
+			nums1:    []int{},
+			nums2:    []int{},
+			expected: 0,
+		},
+		{
+			name:     "Case 5",
+			nums1:    []int{math.MaxInt - 2},
+			nums2:    []int{math.MaxInt},
+			expected: float64(math.MaxInt),
+		},
+		{
+			name:     "Case 6",
+			nums1:    []int{math.MinInt, math.MinInt + 1},
+			nums2:    []int{math.MinInt + 1, math.MaxInt},
+			expected: float64(math.MinInt),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := kway_merge.MedianOfTwoSortedArrays(tt.nums1, tt.nums2); result != tt.expected {
+				t.Errorf("MedianOfTwoSortedArrays(%v, %v) = %v, want %v", tt.nums1, tt.nums2, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMedianOfTwoSortedArraysMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+
+	for round := 0; round < 500; round++ {
+		lists := randomSortedLists(rng, 2, 10, 100)
+		lists[rng.Intn(2)] = lists[rng.Intn(2)][:rng.Intn(2)]
+
+		merged := slices.Sorted(slices.Values(append(slices.Clone(lists[0]), lists[1]...)))
+		n := len(merged)
+		expected := float64(merged[n/2])
+		if n%2 == 0 {
+			expected = float64(merged[n/2-1]+merged[n/2]) / 2
+		}
+
+		if result := kway_merge.MedianOfTwoSortedArrays(lists[0], lists[1]); result != expected {
+			t.Fatalf("MedianOfTwoSortedArrays(%v, %v) = %v, want %v", lists[0], lists[1], result, expected)
+		}
+	}
+}
+
+func TestKthSmallestPrimeFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		arr      []int
+		k        int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			arr:      []int{1, 2, 3, 5},
+			k:        3,
+			expected: []int{2, 5},
+		},
+		{
+			name:     "Case 2",
+			arr:      []int{1, 7},
+			k:        1,
+			expected: []int{1, 7},
+		},
+		{
+			name:     "Case 3",
+			arr:      []int{1, 2, 3, 5},
+			k:        6,
+			expected: []int{2, 3},
+		},
+		{
+			name:     "Case 4",
+			arr:      []int{1, 2, 3},
+			k:        4,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := kway_merge.KthSmallestPrimeFraction(tt.arr, tt.k); !slices.Equal(result, tt.expected) {
+				t.Errorf("KthSmallestPrimeFraction(%v, %d) = %v, want %v", tt.arr, tt.k, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKthSmallestPrimeFractionMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(18))
+	primes := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+	for round := 0; round < 200; round++ {
+		// 1 followed by a random sorted subset of the primes
+		arr := []int{1}
+		for _, p := range primes {
+			if rng.Intn(2) == 0 {
+				arr = append(arr, p)
+			}
+		}
+
+		// distinct primes never form equal fractions, so the order is unique
+		var fractions [][]int
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				fractions = append(fractions, []int{arr[i], arr[j]})
+			}
+		}
+		slices.SortFunc(fractions, func(a, b []int) int { return cmp.Compare(a[0]*b[1], b[0]*a[1]) })
+
+		for k := 1; k <= len(fractions); k++ {
+			if result := kway_merge.KthSmallestPrimeFraction(arr, k); !slices.Equal(result, fractions[k-1]) {
+				t.Fatalf("KthSmallestPrimeFraction(%v, %d) = %v, want %v", arr, k, result, fractions[k-1])
+			}
+		}
+	}
+}
+
+func TestKthSmallestSumOfMatrixRows(t *testing.T) {
+	tests := []struct {
+		name     string
+		matrix   [][]int
+		k        int
+		expected int
+	}{
+		{
+			name:     "Case 1",
+			matrix:   [][]int{{1, 3, 11}, {2, 4, 6}},
+			k:        5,
+			expected: 7,
+		},
+		{
+			name:     "Case 2",
+			matrix:   [][]int{{1, 3, 11}, {2, 4, 6}},
+			k:        9,
+			expected: 17,
+		},
+		{
+			name:     "Case 3",
+			matrix:   [][]int{{1, 10, 10}, {1, 4, 5}, {2, 3, 6}},
+			k:        7,
+			expected: 9,
+		},
+		{
+			name:     "Case 4",
+			matrix:   [][]int{{1, 1, 10}, {2, 2, 9}},
+			k:        7,
+			expected: 12,
+		},
+		{
+			name:     "Case 5",
+			matrix:   [][]int{{1, 2}, {3, 4}},
+			k:        5,
+			expected: -1,
+		},
+		{
+			name:     "Case 6",
+			matrix:   [][]int{},
+			k:        1,
+			expected: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := kway_merge.KthSmallestSumOfMatrixRows(tt.matrix, tt.k); result != tt.expected {
+				t.Errorf("KthSmallestSumOfMatrixRows(%v, %d) = %d, want %d", tt.matrix, tt.k, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKthSmallestSumOfMatrixRowsMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(19))
+
+	for round := 0; round < 300; round++ {
+		matrix := randomSortedLists(rng, 1+rng.Intn(4), 4, 30)
+
+		// every choice of one element per row
+		sums := []int{0}
+		for _, row := range matrix {
+			var next []int
+			for _, sum := range sums {
+				for _, v := range row {
+					next = append(next, sum+v)
+				}
+			}
+			sums = next
+		}
+		slices.Sort(sums)
+
+		k := 1 + rng.Intn(len(sums)+1)
+		expected := -1
+		if k <= len(sums) {
+			expected = sums[k-1]
+		}
+		if result := kway_merge.KthSmallestSumOfMatrixRows(matrix, k); result != expected {
+			t.Fatalf("KthSmallestSumOfMatrixRows(%v, %d) = %d, want %d", matrix, k, result, expected)
+		}
+	}
+}
@@ -168,3 +168,199 @@ func ReorderList(head *structs.LinkedListNode) {
 		fast = nextFast
 	}
 }
+
+// ReverseKGroup reverses the nodes of the list k at a time, the remaining nodes that don't fill a group of k are left as is.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func ReverseKGroup(head *structs.LinkedListNode, k int) *structs.LinkedListNode {
+	if head == nil || k <= 1 {
+		return head
+	}
+
+	// introduce dummy node to help with edge cases when we need to reverse from the head
+	dummy := structs.NewLinkedListNode(0, head)
+	groupPrev := dummy
+	for {
+		// make sure there are still k nodes left to reverse
+		groupEnd := groupPrev
+		for i := 0; i < k && groupEnd != nil; i++ {
+			groupEnd = groupEnd.Next
+		}
+		if groupEnd == nil {
+			break
+		}
+
+		// reverse the group and reconnect it, the first node of the group becomes its last node
+		groupStart := groupPrev.Next
+		groupPrev.Next = reverseNodes(groupStart, k)
+		groupPrev = groupStart
+	}
+
+	return dummy.Next
+}
+
+// RotateRight rotates the list to the right by k places.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func RotateRight(head *structs.LinkedListNode, k int) *structs.LinkedListNode {
+	if head == nil || head.Next == nil || k <= 0 {
+		return head
+	}
+
+	// find the length and the tail of the linked list
+	length, tail := 1, head
+	for tail.Next != nil {
+		tail = tail.Next
+		length++
+	}
+
+	// rotating by a multiple of the length gives back the same list
+	k %= length
+	if k == 0 {
+		return head
+	}
+
+	// the new tail is at position length - k, the node after it becomes the new head
+	newTail := head
+	for i := 1; i < length-k; i++ {
+		newTail = newTail.Next
+	}
+	newHead := newTail.Next
+	newTail.Next = nil
+	tail.Next = head
+
+	return newHead
+}
+
+// ReverseAlternateKNodes reverses the first k nodes, keeps the next k nodes, reverses the next k nodes and so on.
+// A remaining group shorter than k is reversed as well if it falls on a reversing turn.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func ReverseAlternateKNodes(head *structs.LinkedListNode, k int) *structs.LinkedListNode {
+	if head == nil || k <= 1 {
+		return head
+	}
+
+	dummy := structs.NewLinkedListNode(0, head)
+	groupPrev := dummy
+	for groupPrev.Next != nil {
+		// count the nodes of the group to reverse, the last group might be shorter than k
+		count, node := 0, groupPrev.Next
+		for count < k && node != nil {
+			node = node.Next
+			count++
+		}
+
+		// reverse the group, the first node of the group becomes its last node
+		groupStart := groupPrev.Next
+		groupPrev.Next = reverseNodes(groupStart, count)
+		groupPrev = groupStart
+
+		// skip the next k nodes
+		for i := 0; i < k && groupPrev.Next != nil; i++ {
+			groupPrev = groupPrev.Next
+		}
+	}
+
+	return dummy.Next
+}
+
+// OddEvenList groups all the nodes at odd positions together followed by the nodes at even positions,
+// keeping the relative order inside both groups.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func OddEvenList(head *structs.LinkedListNode) *structs.LinkedListNode {
+	if head == nil || head.Next == nil {
+		return head
+	}
+
+	// weave the odd and even nodes into two separate lists
+	odd, even := head, head.Next
+	evenHead := even
+	for even != nil && even.Next != nil {
+		odd.Next = even.Next
+		odd = odd.Next
+		even.Next = odd.Next
+		even = even.Next
+	}
+
+	// connect the last odd node to the first even node
+	odd.Next = evenHead
+	return head
+}
+
+// PartitionAroundValue moves all the nodes with data less than x before the nodes with data greater than or equal to x,
+// keeping the relative order inside both partitions.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func PartitionAroundValue(head *structs.LinkedListNode, x int) *structs.LinkedListNode {
+	// use dummy nodes as heads of the two partitions
+	lessDummy := structs.NewLinkedListNode(0, nil)
+	greaterDummy := structs.NewLinkedListNode(0, nil)
+	less, greater := lessDummy, greaterDummy
+
+	for current := head; current != nil; current = current.Next {
+		if current.Data < x {
+			less.Next = current
+			less = less.Next
+		} else {
+			greater.Next = current
+			greater = greater.Next
+		}
+	}
+
+	// terminate the greater partition and connect it after the less partition
+	greater.Next = nil
+	less.Next = greaterDummy.Next
+
+	return lessDummy.Next
+}
+
+// SwapKthFromEnds swaps the k-th node from the beginning with the k-th node from the end by relinking the nodes.
+// If k is out of range, the list is returned as is.
+// This solution has time complexity of O(n) and space complexity of O(1).
+func SwapKthFromEnds(head *structs.LinkedListNode, k int) *structs.LinkedListNode {
+	if head == nil || k <= 0 {
+		return head
+	}
+
+	dummy := structs.NewLinkedListNode(0, head)
+
+	// move the front pointer to the node before the k-th node from the beginning
+	frontPrev := dummy
+	for i := 1; i < k; i++ {
+		frontPrev = frontPrev.Next
+		if frontPrev.Next == nil {
+			return head
+		}
+	}
+
+	// keep the runner k nodes ahead of the back pointer, when the runner reaches the last node
+	// the back pointer is at the node before the k-th node from the end
+	endPrev, runner := dummy, frontPrev.Next
+	for runner.Next != nil {
+		runner = runner.Next
+		endPrev = endPrev.Next
+	}
+
+	front, back := frontPrev.Next, endPrev.Next
+	if front == back {
+		return head
+	}
+
+	// swap the links of the predecessors and then the links of the nodes themselves,
+	// the parallel assignments also hold when one node is the predecessor of the other
+	frontPrev.Next, endPrev.Next = back, front
+	front.Next, back.Next = back.Next, front.Next
+
+	return dummy.Next
+}
+
+// reverseNodes reverses the first count nodes starting at head and returns the new first node,
+// the original head ends up as the last reversed node and is connected to the rest of the list
+func reverseNodes(head *structs.LinkedListNode, count int) *structs.LinkedListNode {
+	prev, curr := (*structs.LinkedListNode)(nil), head
+	for i := 0; i < count; i++ {
+		next := curr.Next
+		curr.Next = prev
+		prev = curr
+		curr = next
+	}
+	head.Next = curr
+	return prev
+}
@@ -231,3 +231,351 @@ func TestReorderList(t *testing.T) {
 		}
 	}
 }
+
+func TestReverseKGroup(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 2, 3, 4, 5},
+			k:        2,
+			expected: []int{2, 1, 4, 3, 5},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{1, 2, 3, 4, 5},
+			k:        3,
+			expected: []int{3, 2, 1, 4, 5},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1, 2, 3, 4, 5, 6},
+			k:        3,
+			expected: []int{3, 2, 1, 6, 5, 4},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{1, 2, 3},
+			k:        1,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{1, 2},
+			k:        3,
+			expected: []int{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := &structs.LinkedList{}
+		ll.CreateLinkedList(tc.nums)
+
+		got := linked_list_in_place_manipulation.ReverseKGroup(ll.Head, tc.k)
+
+		llResult := &structs.LinkedList{}
+		llResult.InsertNodeAtHead(got)
+
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.expected)
+
+		if llResult.String() != llExpected.String() {
+			t.Errorf("ReverseKGroup(%v, %d) = %v, expected %v", tc.nums, tc.k, llResult.String(), llExpected.String())
+		}
+	}
+}
+
+func TestRotateRight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 2, 3, 4, 5},
+			k:        2,
+			expected: []int{4, 5, 1, 2, 3},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{0, 1, 2},
+			k:        4,
+			expected: []int{2, 0, 1},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1, 2, 3},
+			k:        3,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{1},
+			k:        99,
+			expected: []int{1},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{10, 20, 30, 40},
+			k:        1,
+			expected: []int{40, 10, 20, 30},
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := &structs.LinkedList{}
+		ll.CreateLinkedList(tc.nums)
+
+		got := linked_list_in_place_manipulation.RotateRight(ll.Head, tc.k)
+
+		llResult := &structs.LinkedList{}
+		llResult.InsertNodeAtHead(got)
+
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.expected)
+
+		if llResult.String() != llExpected.String() {
+			t.Errorf("RotateRight(%v, %d) = %v, expected %v", tc.nums, tc.k, llResult.String(), llExpected.String())
+		}
+	}
+}
+
+func TestReverseAlternateKNodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			k:        3,
+			expected: []int{3, 2, 1, 4, 5, 6, 9, 8, 7},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{1, 2, 3, 4, 5, 6, 7, 8},
+			k:        2,
+			expected: []int{2, 1, 3, 4, 6, 5, 7, 8},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1, 2, 3, 4, 5, 6, 7},
+			k:        3,
+			expected: []int{3, 2, 1, 4, 5, 6, 7},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{1, 2, 3, 4, 5},
+			k:        2,
+			expected: []int{2, 1, 3, 4, 5},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			k:        4,
+			expected: []int{4, 3, 2, 1, 5, 6, 7, 8, 10, 9},
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := &structs.LinkedList{}
+		ll.CreateLinkedList(tc.nums)
+
+		got := linked_list_in_place_manipulation.ReverseAlternateKNodes(ll.Head, tc.k)
+
+		llResult := &structs.LinkedList{}
+		llResult.InsertNodeAtHead(got)
+
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.expected)
+
+		if llResult.String() != llExpected.String() {
+			t.Errorf("ReverseAlternateKNodes(%v, %d) = %v, expected %v", tc.nums, tc.k, llResult.String(), llExpected.String())
+		}
+	}
+}
+
+func TestOddEvenList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nums     []int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 2, 3, 4, 5},
+			expected: []int{1, 3, 5, 2, 4},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{2, 1, 3, 5, 6, 4, 7},
+			expected: []int{2, 3, 6, 7, 1, 5, 4},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1},
+			expected: []int{1},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{1, 2},
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{1, 2, 3, 4},
+			expected: []int{1, 3, 2, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := &structs.LinkedList{}
+		ll.CreateLinkedList(tc.nums)
+
+		got := linked_list_in_place_manipulation.OddEvenList(ll.Head)
+
+		llResult := &structs.LinkedList{}
+		llResult.InsertNodeAtHead(got)
+
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.expected)
+
+		if llResult.String() != llExpected.String() {
+			t.Errorf("OddEvenList(%v) = %v, expected %v", tc.nums, llResult.String(), llExpected.String())
+		}
+	}
+}
+
+func TestPartitionAroundValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nums     []int
+		x        int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 4, 3, 2, 5, 2},
+			x:        3,
+			expected: []int{1, 2, 2, 4, 3, 5},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{2, 1},
+			x:        2,
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{5, 6, 7},
+			x:        1,
+			expected: []int{5, 6, 7},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{3, 1, 2},
+			x:        10,
+			expected: []int{3, 1, 2},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{7, -1, 3, 0, 3, 8},
+			x:        3,
+			expected: []int{-1, 0, 7, 3, 3, 8},
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := &structs.LinkedList{}
+		ll.CreateLinkedList(tc.nums)
+
+		got := linked_list_in_place_manipulation.PartitionAroundValue(ll.Head, tc.x)
+
+		llResult := &structs.LinkedList{}
+		llResult.InsertNodeAtHead(got)
+
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.expected)
+
+		if llResult.String() != llExpected.String() {
+			t.Errorf("PartitionAroundValue(%v, %d) = %v, expected %v", tc.nums, tc.x, llResult.String(), llExpected.String())
+		}
+	}
+}
+
+func TestSwapKthFromEnds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 2, 3, 4, 5},
+			k:        2,
+			expected: []int{1, 4, 3, 2, 5},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{7, 9, 6, 6, 7, 8, 3, 0, 9, 5},
+			k:        5,
+			expected: []int{7, 9, 6, 6, 8, 7, 3, 0, 9, 5},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1},
+			k:        1,
+			expected: []int{1},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{1, 2},
+			k:        1,
+			expected: []int{2, 1},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{1, 2, 3},
+			k:        2,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "Case 6",
+			nums:     []int{1, 2, 3, 4},
+			k:        3,
+			expected: []int{1, 3, 2, 4},
+		},
+		{
+			name:     "Case 7",
+			nums:     []int{1, 2, 3},
+			k:        5,
+			expected: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		ll := &structs.LinkedList{}
+		ll.CreateLinkedList(tc.nums)
+
+		got := linked_list_in_place_manipulation.SwapKthFromEnds(ll.Head, tc.k)
+
+		llResult := &structs.LinkedList{}
+		llResult.InsertNodeAtHead(got)
+
+		llExpected := &structs.LinkedList{}
+		llExpected.CreateLinkedList(tc.expected)
+
+		if llResult.String() != llExpected.String() {
+			t.Errorf("SwapKthFromEnds(%v, %d) = %v, expected %v", tc.nums, tc.k, llResult.String(), llExpected.String())
+		}
+	}
+}
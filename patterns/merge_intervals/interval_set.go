@@ -0,0 +1,310 @@
+package merge_intervals
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Interval is a range of the number line between Start and End.
+// Each endpoint is either closed (included in the interval) or open (excluded from the interval).
+// An interval is empty when Start > End, or when Start == End and any endpoint is open.
+type Interval struct {
+	Start     int
+	End       int
+	StartOpen bool
+	EndOpen   bool
+}
+
+// Closed returns the interval [start, end]
+func Closed(start, end int) Interval {
+	return Interval{Start: start, End: end}
+}
+
+// Open returns the interval (start, end)
+func Open(start, end int) Interval {
+	return Interval{Start: start, End: end, StartOpen: true, EndOpen: true}
+}
+
+// ClosedOpen returns the interval [start, end)
+func ClosedOpen(start, end int) Interval {
+	return Interval{Start: start, End: end, EndOpen: true}
+}
+
+// OpenClosed returns the interval (start, end]
+func OpenClosed(start, end int) Interval {
+	return Interval{Start: start, End: end, StartOpen: true}
+}
+
+// IsEmpty checks if the interval doesn't contain any point
+func (i Interval) IsEmpty() bool {
+	return i.Start > i.End || (i.Start == i.End && (i.StartOpen || i.EndOpen))
+}
+
+// Length returns the length covered by the interval, open endpoints don't change the length
+func (i Interval) Length() int {
+	if i.IsEmpty() {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// Contains checks if the point p is inside the interval
+func (i Interval) Contains(p int) bool {
+	afterStart := p > i.Start || (p == i.Start && !i.StartOpen)
+	beforeEnd := p < i.End || (p == i.End && !i.EndOpen)
+	return afterStart && beforeEnd
+}
+
+// Intersect returns the interval covered by both intervals, the second return value is false if they don't overlap
+func (i Interval) Intersect(other Interval) (Interval, bool) {
+	// the intersection starts at the later start and ends at the earlier end
+	start, end := i, i
+	if compareStarts(other, i) > 0 {
+		start = other
+	}
+	if compareEnds(other, i) < 0 {
+		end = other
+	}
+
+	intersection := Interval{Start: start.Start, StartOpen: start.StartOpen, End: end.End, EndOpen: end.EndOpen}
+	if intersection.IsEmpty() {
+		return Interval{}, false
+	}
+	return intersection, true
+}
+
+// Overlaps checks if both intervals share at least one point
+func (i Interval) Overlaps(other Interval) bool {
+	_, ok := i.Intersect(other)
+	return ok
+}
+
+// String returns the interval in math notation, e.g. [1, 5)
+func (i Interval) String() string {
+	left, right := "[", "]"
+	if i.StartOpen {
+		left = "("
+	}
+	if i.EndOpen {
+		right = ")"
+	}
+	return fmt.Sprintf("%s%d, %d%s", left, i.Start, i.End, right)
+}
+
+// IntervalSet is a set of points on the number line stored as sorted, non-overlapping and non-touching intervals.
+// The zero value is an empty set ready to use.
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// NewIntervalSet will initialize and return a new IntervalSet covering the given intervals.
+// This has time complexity of O(n log n) because the intervals are sorted before merging.
+func NewIntervalSet(intervals ...Interval) *IntervalSet {
+	return &IntervalSet{intervals: normalize(slices.Clone(intervals))}
+}
+
+// Intervals returns a copy of the sorted, non-overlapping intervals of the set
+func (s *IntervalSet) Intervals() []Interval {
+	return slices.Clone(s.intervals)
+}
+
+// Len returns the number of disjoint intervals in the set
+func (s *IntervalSet) Len() int {
+	return len(s.intervals)
+}
+
+// Add adds the interval into the set, merging it with every interval it overlaps or touches.
+// This has time complexity of O(n).
+func (s *IntervalSet) Add(interval Interval) {
+	if interval.IsEmpty() {
+		return
+	}
+
+	// keep the intervals ending before the new interval, merge the ones overlapping it and keep the rest
+	result := make([]Interval, 0, len(s.intervals)+1)
+	i, n := 0, len(s.intervals)
+	for i < n && !mergeable(s.intervals[i], interval) && compareStarts(s.intervals[i], interval) < 0 {
+		result = append(result, s.intervals[i])
+		i++
+	}
+	for i < n && (mergeable(s.intervals[i], interval) || mergeable(interval, s.intervals[i])) {
+		interval = merge(interval, s.intervals[i])
+		i++
+	}
+	result = append(result, interval)
+	result = append(result, s.intervals[i:]...)
+	s.intervals = result
+}
+
+// Remove removes every point of the interval from the set.
+// This has time complexity of O(n).
+func (s *IntervalSet) Remove(interval Interval) {
+	if interval.IsEmpty() {
+		return
+	}
+
+	result := make([]Interval, 0, len(s.intervals)+1)
+	for _, current := range s.intervals {
+		result = append(result, subtract(current, interval)...)
+	}
+	s.intervals = result
+}
+
+// Contains checks if the point p is covered by the set.
+// This has time complexity of O(log n).
+func (s *IntervalSet) Contains(p int) bool {
+	// find the first interval which doesn't end before p
+	index, _ := slices.BinarySearchFunc(s.intervals, p, func(interval Interval, p int) int {
+		if interval.End < p || (interval.End == p && interval.EndOpen) {
+			return -1
+		}
+		return 1
+	})
+	return index < len(s.intervals) && s.intervals[index].Contains(p)
+}
+
+// Length returns the total length covered by the set
+func (s *IntervalSet) Length() int {
+	length := 0
+	for _, interval := range s.intervals {
+		length += interval.Length()
+	}
+	return length
+}
+
+// Union returns a new set covering the points of both sets.
+// This has time complexity of O((n + m) log(n + m)).
+func (s *IntervalSet) Union(other *IntervalSet) *IntervalSet {
+	return NewIntervalSet(append(slices.Clone(s.intervals), other.intervals...)...)
+}
+
+// Intersection returns a new set covering the points that are in both sets.
+// Uses two pointers over both sorted sets, this has time complexity of O(n + m).
+func (s *IntervalSet) Intersection(other *IntervalSet) *IntervalSet {
+	result := &IntervalSet{}
+	i, j := 0, 0
+	for i < len(s.intervals) && j < len(other.intervals) {
+		if intersection, ok := s.intervals[i].Intersect(other.intervals[j]); ok {
+			result.intervals = append(result.intervals, intersection)
+		}
+
+		// move forward the interval which ends first, it can't overlap anything else
+		if compareEnds(s.intervals[i], other.intervals[j]) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a new set covering the points that are in s but not in other.
+// This has time complexity of O(n * m).
+func (s *IntervalSet) Difference(other *IntervalSet) *IntervalSet {
+	result := &IntervalSet{intervals: slices.Clone(s.intervals)}
+	for _, interval := range other.intervals {
+		result.Remove(interval)
+	}
+	return result
+}
+
+// Gaps returns the intervals within the given range that are not covered by the set
+func (s *IntervalSet) Gaps(within Interval) []Interval {
+	return NewIntervalSet(within).Difference(s).intervals
+}
+
+// String returns the intervals of the set, e.g. {[1, 3), [5, 8]}
+func (s *IntervalSet) String() string {
+	str := "{"
+	for i, interval := range s.intervals {
+		if i > 0 {
+			str += ", "
+		}
+		str += interval.String()
+	}
+	return str + "}"
+}
+
+// compareStarts compares where the intervals start, a closed start comes before an open start on the same point
+func compareStarts(a, b Interval) int {
+	if a.Start != b.Start {
+		return cmp.Compare(a.Start, b.Start)
+	}
+	if a.StartOpen == b.StartOpen {
+		return 0
+	}
+	if b.StartOpen {
+		return -1
+	}
+	return 1
+}
+
+// compareEnds compares where the intervals end, an open end comes before a closed end on the same point
+func compareEnds(a, b Interval) int {
+	if a.End != b.End {
+		return cmp.Compare(a.End, b.End)
+	}
+	if a.EndOpen == b.EndOpen {
+		return 0
+	}
+	if a.EndOpen {
+		return -1
+	}
+	return 1
+}
+
+// mergeable checks if b, which doesn't start before a, overlaps or touches a so that their union is one interval
+func mergeable(a, b Interval) bool {
+	if compareStarts(a, b) > 0 {
+		return false
+	}
+	return a.End > b.Start || (a.End == b.Start && !(a.EndOpen && b.StartOpen))
+}
+
+// merge returns the smallest interval covering both intervals
+func merge(a, b Interval) Interval {
+	if compareStarts(b, a) < 0 {
+		a.Start, a.StartOpen = b.Start, b.StartOpen
+	}
+	if compareEnds(b, a) > 0 {
+		a.End, a.EndOpen = b.End, b.EndOpen
+	}
+	return a
+}
+
+// subtract returns the parts of a that are not covered by b
+func subtract(a, b Interval) []Interval {
+	if !a.Overlaps(b) {
+		return []Interval{a}
+	}
+
+	var parts []Interval
+	// the part of a before b starts, it ends where b starts with the opposite endpoint type
+	before := Interval{Start: a.Start, StartOpen: a.StartOpen, End: b.Start, EndOpen: !b.StartOpen}
+	if part, ok := before.Intersect(a); ok {
+		parts = append(parts, part)
+	}
+	// the part of a after b ends, it starts where b ends with the opposite endpoint type
+	after := Interval{Start: b.End, StartOpen: !b.EndOpen, End: a.End, EndOpen: a.EndOpen}
+	if part, ok := after.Intersect(a); ok {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// normalize sorts the intervals by their start, drops the empty ones and merges the ones overlapping or touching
+func normalize(intervals []Interval) []Interval {
+	intervals = slices.DeleteFunc(intervals, Interval.IsEmpty)
+	slices.SortFunc(intervals, compareStarts)
+
+	var result []Interval
+	for _, interval := range intervals {
+		if len(result) > 0 && mergeable(result[len(result)-1], interval) {
+			result[len(result)-1] = merge(result[len(result)-1], interval)
+		} else {
+			result = append(result, interval)
+		}
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+package merge_intervals_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/patterns/merge_intervals"
+)
+
+func TestIntervalSet(t *testing.T) {
+	testCases := []struct {
+		name         string
+		left         []merge_intervals.Interval
+		right        []merge_intervals.Interval
+		union        string
+		intersection string
+		difference   string
+		length       int
+	}{
+		{
+			name:         "Case 1",
+			left:         []merge_intervals.Interval{merge_intervals.Closed(1, 5), merge_intervals.Closed(3, 7), merge_intervals.Closed(10, 12)},
+			right:        []merge_intervals.Interval{merge_intervals.Closed(6, 11)},
+			union:        "{[1, 12]}",
+			intersection: "{[6, 7], [10, 11]}",
+			difference:   "{[1, 6), (11, 12]}",
+			length:       8,
+		},
+		{
+			name:         "Case 2",
+			left:         []merge_intervals.Interval{merge_intervals.ClosedOpen(1, 3), merge_intervals.Closed(3, 5)},
+			right:        []merge_intervals.Interval{merge_intervals.Open(2, 4)},
+			union:        "{[1, 5]}",
+			intersection: "{(2, 4)}",
+			difference:   "{[1, 2], [4, 5]}",
+			length:       4,
+		},
+		{
+			name:         "Case 3",
+			left:         []merge_intervals.Interval{merge_intervals.ClosedOpen(1, 3), merge_intervals.OpenClosed(3, 5)},
+			right:        []merge_intervals.Interval{merge_intervals.Closed(3, 3)},
+			union:        "{[1, 5]}",
+			intersection: "{}",
+			difference:   "{[1, 3), (3, 5]}",
+			length:       4,
+		},
+		{
+			name:         "Case 4",
+			left:         []merge_intervals.Interval{merge_intervals.Closed(8, 9), merge_intervals.Open(4, 4), merge_intervals.Closed(1, 2)},
+			right:        []merge_intervals.Interval{},
+			union:        "{[1, 2], [8, 9]}",
+			intersection: "{}",
+			difference:   "{[1, 2], [8, 9]}",
+			length:       2,
+		},
+		{
+			name:         "Case 5",
+			left:         []merge_intervals.Interval{merge_intervals.Closed(0, 10)},
+			right:        []merge_intervals.Interval{merge_intervals.Closed(2, 3), merge_intervals.ClosedOpen(5, 6), merge_intervals.Closed(9, 12)},
+			union:        "{[0, 12]}",
+			intersection: "{[2, 3], [5, 6), [9, 10]}",
+			difference:   "{[0, 2), (3, 5), [6, 9)}",
+			length:       10,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			left := merge_intervals.NewIntervalSet(test.left...)
+			right := merge_intervals.NewIntervalSet(test.right...)
+
+			if got := left.Union(right).String(); got != test.union {
+				t.Errorf("Union(%v, %v) = %v, want %v", left, right, got, test.union)
+			}
+			if got := left.Intersection(right).String(); got != test.intersection {
+				t.Errorf("Intersection(%v, %v) = %v, want %v", left, right, got, test.intersection)
+			}
+			if got := left.Difference(right).String(); got != test.difference {
+				t.Errorf("Difference(%v, %v) = %v, want %v", left, right, got, test.difference)
+			}
+			if got := left.Length(); got != test.length {
+				t.Errorf("Length(%v) = %v, want %v", left, got, test.length)
+			}
+		})
+	}
+}
+
+func TestIntervalSetAddRemoveAndQueries(t *testing.T) {
+	var set merge_intervals.IntervalSet
+	set.Add(merge_intervals.Closed(1, 3))
+	set.Add(merge_intervals.Closed(6, 9))
+	set.Add(merge_intervals.OpenClosed(3, 4))
+	set.Add(merge_intervals.Open(12, 12))
+	if got := set.String(); got != "{[1, 4], [6, 9]}" {
+		t.Errorf("Add = %v, want %v", got, "{[1, 4], [6, 9]}")
+	}
+
+	set.Remove(merge_intervals.Open(7, 8))
+	if got := set.String(); got != "{[1, 4], [6, 7], [8, 9]}" {
+		t.Errorf("Remove = %v, want %v", got, "{[1, 4], [6, 7], [8, 9]}")
+	}
+
+	gaps := set.Gaps(merge_intervals.Closed(0, 10))
+	expectedGaps := []merge_intervals.Interval{
+		merge_intervals.ClosedOpen(0, 1), merge_intervals.Open(4, 6), merge_intervals.Open(7, 8), merge_intervals.OpenClosed(9, 10),
+	}
+	if !reflect.DeepEqual(gaps, expectedGaps) {
+		t.Errorf("Gaps = %v, want %v", gaps, expectedGaps)
+	}
+
+	for p, expected := range map[int]bool{0: false, 1: true, 4: true, 5: false, 7: true, 8: true, 10: false} {
+		if got := set.Contains(p); got != expected {
+			t.Errorf("Contains(%d) on %v = %v, want %v", p, set.String(), got, expected)
+		}
+	}
+}
+
+// containsHalfPoint checks if the point q/2 is inside any of the intervals,
+// doubling the coordinates lets us probe both the integer points and the points between them
+func containsHalfPoint(intervals []merge_intervals.Interval, q int) bool {
+	for _, interval := range intervals {
+		start, end := 2*interval.Start, 2*interval.End
+		afterStart := q > start || (q == start && !interval.StartOpen)
+		beforeEnd := q < end || (q == end && !interval.EndOpen)
+		if afterStart && beforeEnd {
+			return true
+		}
+	}
+	return false
+}
+
+func randomIntervals(rng *rand.Rand) []merge_intervals.Interval {
+	intervals := make([]merge_intervals.Interval, rng.Intn(5))
+	for i := range intervals {
+		start := rng.Intn(10)
+		intervals[i] = merge_intervals.Interval{
+			Start:     start,
+			End:       start + rng.Intn(4),
+			StartOpen: rng.Intn(2) == 0,
+			EndOpen:   rng.Intn(2) == 0,
+		}
+	}
+	return intervals
+}
+
+func TestIntervalSetMatchesPointwise(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for round := 0; round < 2000; round++ {
+		leftIntervals, rightIntervals := randomIntervals(rng), randomIntervals(rng)
+		left := merge_intervals.NewIntervalSet(leftIntervals...)
+		right := merge_intervals.NewIntervalSet(rightIntervals...)
+
+		union := left.Union(right).Intervals()
+		intersection := left.Intersection(right).Intervals()
+		difference := left.Difference(right).Intervals()
+
+		// adding or removing the intervals one by one gives the same sets
+		added, removed := merge_intervals.NewIntervalSet(leftIntervals...), merge_intervals.NewIntervalSet(leftIntervals...)
+		for _, interval := range rightIntervals {
+			added.Add(interval)
+			removed.Remove(interval)
+		}
+		if !reflect.DeepEqual(added.Intervals(), union) {
+			t.Fatalf("Add(%v) to %v = %v, want %v", rightIntervals, left, added, union)
+		}
+		if !reflect.DeepEqual(removed.Intervals(), difference) {
+			t.Fatalf("Remove(%v) from %v = %v, want %v", rightIntervals, left, removed, difference)
+		}
+
+		length := 0
+		for q := -2; q <= 30; q++ {
+			inLeft, inRight := containsHalfPoint(leftIntervals, q), containsHalfPoint(rightIntervals, q)
+			if containsHalfPoint(left.Intervals(), q) != inLeft {
+				t.Fatalf("NewIntervalSet(%v) = %v differs at %v", leftIntervals, left, float64(q)/2)
+			}
+			if containsHalfPoint(union, q) != (inLeft || inRight) {
+				t.Fatalf("Union(%v, %v) = %v differs at %v", left, right, union, float64(q)/2)
+			}
+			if containsHalfPoint(intersection, q) != (inLeft && inRight) {
+				t.Fatalf("Intersection(%v, %v) = %v differs at %v", left, right, intersection, float64(q)/2)
+			}
+			if containsHalfPoint(difference, q) != (inLeft && !inRight) {
+				t.Fatalf("Difference(%v, %v) = %v differs at %v", left, right, difference, float64(q)/2)
+			}
+			if q%2 == 0 && left.Contains(q/2) != inLeft {
+				t.Fatalf("Contains(%d) on %v = %v, want %v", q/2, left, !inLeft, inLeft)
+			}
+			// every unit segment between integers is either fully covered or not, its midpoint tells which
+			if q%2 != 0 && inLeft {
+				length++
+			}
+		}
+		if left.Length() != length {
+			t.Fatalf("Length(%v) = %d, want %d", left, left.Length(), length)
+		}
+
+		// the intervals of a set are sorted and separated by a gap
+		for i := 1; i < len(union); i++ {
+			prev, curr := union[i-1], union[i]
+			if prev.End > curr.Start || (prev.End == curr.Start && !(prev.EndOpen && curr.StartOpen)) {
+				t.Fatalf("Union(%v, %v) = %v is not normalized", left, right, union)
+			}
+		}
+	}
+}
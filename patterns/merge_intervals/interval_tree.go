@@ -0,0 +1,262 @@
+package merge_intervals
+
+import "iter"
+
+// IntervalTree is a collection of intervals which answers overlap queries without scanning every interval.
+// It is an AVL tree ordered by the interval start, where every node is augmented with the greatest end in its subtree,
+// so whole subtrees ending before a query can be skipped.
+// The same interval may be inserted several times. The zero value is an empty tree ready to use.
+type IntervalTree struct {
+	root *intervalTreeNode
+	size int
+}
+
+// intervalTreeNode holds an interval, the number of times it was inserted,
+// the height of its subtree and the interval of its subtree with the greatest end
+type intervalTreeNode struct {
+	interval Interval
+	count    int
+	height   int
+	maxEnd   Interval
+	left     *intervalTreeNode
+	right    *intervalTreeNode
+}
+
+// NewIntervalTree will initialize and return a new IntervalTree containing the given intervals
+func NewIntervalTree(intervals ...Interval) *IntervalTree {
+	tree := new(IntervalTree)
+	for _, interval := range intervals {
+		tree.Insert(interval)
+	}
+	return tree
+}
+
+// Len returns the number of intervals in the tree
+func (t *IntervalTree) Len() int {
+	return t.size
+}
+
+// Insert inserts the interval into the tree, empty intervals are ignored, this has time complexity of O(log n)
+func (t *IntervalTree) Insert(interval Interval) {
+	if interval.IsEmpty() {
+		return
+	}
+	t.root = insertNode(t.root, interval)
+	t.size++
+}
+
+// Delete deletes one occurrence of the interval from the tree, it returns false if the interval is not in the tree.
+// This has time complexity of O(log n).
+func (t *IntervalTree) Delete(interval Interval) bool {
+	var deleted bool
+	t.root, deleted = deleteNode(t.root, interval)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// Overlapping returns every interval of the tree which shares at least one point with the query, ordered by start.
+// Every reported interval may cost a path of O(log n) nodes whose subtrees reach the query but don't overlap it,
+// so this has time complexity of O(min(n, k log n)) where k is the number of reported intervals.
+func (t *IntervalTree) Overlapping(query Interval) []Interval {
+	var result []Interval
+	if query.IsEmpty() {
+		return result
+	}
+	collectOverlapping(t.root, query, &result)
+	return result
+}
+
+// AnyContaining returns an interval of the tree which contains the point p, the second return value is false if there is none.
+// It follows a single path from the root, so this has time complexity of O(log n).
+func (t *IntervalTree) AnyContaining(p int) (Interval, bool) {
+	node := t.root
+	for node != nil {
+		if node.interval.Contains(p) {
+			return node.interval, true
+		}
+
+		// if the left subtree reaches p, an interval containing p can only be there:
+		// the intervals reaching p on the left start after p if they don't contain it, and so does the right subtree
+		if node.left != nil && !endsBeforePoint(node.left.maxEnd, p) {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return Interval{}, false
+}
+
+// All returns an iterator over the intervals of the tree ordered by start and then by end, iterating all of them takes O(n)
+func (t *IntervalTree) All() iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		walkInOrder(t.root, yield)
+	}
+}
+
+// compareIntervals orders intervals by their start and then by their end
+func compareIntervals(a, b Interval) int {
+	if c := compareStarts(a, b); c != 0 {
+		return c
+	}
+	return compareEnds(a, b)
+}
+
+// endsBeforePoint checks if the interval ends before the point p
+func endsBeforePoint(interval Interval, p int) bool {
+	return interval.End < p || (interval.End == p && interval.EndOpen)
+}
+
+// endsBefore checks if interval a ends before interval b starts, so they can't overlap
+func endsBefore(a, b Interval) bool {
+	return a.End < b.Start || (a.End == b.Start && (a.EndOpen || b.StartOpen))
+}
+
+func collectOverlapping(node *intervalTreeNode, query Interval, result *[]Interval) {
+	// nothing in this subtree reaches the query
+	if node == nil || endsBefore(node.maxEnd, query) {
+		return
+	}
+
+	collectOverlapping(node.left, query, result)
+	if node.interval.Overlaps(query) {
+		for i := 0; i < node.count; i++ {
+			*result = append(*result, node.interval)
+		}
+	}
+
+	// the node and everything on its right start after the query ends
+	if endsBefore(query, node.interval) {
+		return
+	}
+	collectOverlapping(node.right, query, result)
+}
+
+func walkInOrder(node *intervalTreeNode, yield func(Interval) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !walkInOrder(node.left, yield) {
+		return false
+	}
+	for i := 0; i < node.count; i++ {
+		if !yield(node.interval) {
+			return false
+		}
+	}
+	return walkInOrder(node.right, yield)
+}
+
+func insertNode(node *intervalTreeNode, interval Interval) *intervalTreeNode {
+	if node == nil {
+		return &intervalTreeNode{interval: interval, count: 1, height: 1, maxEnd: interval}
+	}
+
+	switch c := compareIntervals(interval, node.interval); {
+	case c < 0:
+		node.left = insertNode(node.left, interval)
+	case c > 0:
+		node.right = insertNode(node.right, interval)
+	default:
+		node.count++
+		return node
+	}
+	return rebalance(node)
+}
+
+func deleteNode(node *intervalTreeNode, interval Interval) (*intervalTreeNode, bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch c := compareIntervals(interval, node.interval); {
+	case c < 0:
+		node.left, deleted = deleteNode(node.left, interval)
+	case c > 0:
+		node.right, deleted = deleteNode(node.right, interval)
+	default:
+		deleted = true
+		if node.count > 1 {
+			node.count--
+			return node, deleted
+		}
+
+		// a node with at most one child is replaced by that child
+		if node.left == nil {
+			return node.right, deleted
+		}
+		if node.right == nil {
+			return node.left, deleted
+		}
+
+		// otherwise the node takes over its in-order successor, which is then removed from the right subtree
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.interval, node.count = successor.interval, successor.count
+		successor.count = 1
+		node.right, _ = deleteNode(node.right, successor.interval)
+	}
+	if !deleted {
+		return node, deleted
+	}
+	return rebalance(node), deleted
+}
+
+func height(node *intervalTreeNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// update recalculates the height and the greatest end of the node from its children
+func update(node *intervalTreeNode) {
+	node.height = 1 + max(height(node.left), height(node.right))
+	node.maxEnd = node.interval
+	for _, child := range []*intervalTreeNode{node.left, node.right} {
+		if child != nil && compareEnds(child.maxEnd, node.maxEnd) > 0 {
+			node.maxEnd = child.maxEnd
+		}
+	}
+}
+
+func rotateLeft(node *intervalTreeNode) *intervalTreeNode {
+	pivot := node.right
+	node.right = pivot.left
+	pivot.left = node
+	update(node)
+	update(pivot)
+	return pivot
+}
+
+func rotateRight(node *intervalTreeNode) *intervalTreeNode {
+	pivot := node.left
+	node.left = pivot.right
+	pivot.right = node
+	update(node)
+	update(pivot)
+	return pivot
+}
+
+// rebalance restores the AVL property of the node, whose subtrees differ in height by at most 2
+func rebalance(node *intervalTreeNode) *intervalTreeNode {
+	update(node)
+	balance := height(node.left) - height(node.right)
+	if balance > 1 {
+		if height(node.left.left) < height(node.left.right) {
+			node.left = rotateLeft(node.left)
+		}
+		return rotateRight(node)
+	}
+	if balance < -1 {
+		if height(node.right.right) < height(node.right.left) {
+			node.right = rotateRight(node.right)
+		}
+		return rotateLeft(node)
+	}
+	return node
+}
@@ -0,0 +1,153 @@
+package merge_intervals_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/patterns/merge_intervals"
+)
+
+func TestIntervalTree(t *testing.T) {
+	tree := merge_intervals.NewIntervalTree(
+		merge_intervals.Closed(15, 20),
+		merge_intervals.Closed(10, 30),
+		merge_intervals.Closed(17, 19),
+		merge_intervals.Closed(5, 20),
+		merge_intervals.Closed(12, 15),
+		merge_intervals.Closed(30, 40),
+		merge_intervals.Closed(12, 15),
+		merge_intervals.Open(3, 3),
+	)
+	if tree.Len() != 7 {
+		t.Errorf("Len() = %d, want %d", tree.Len(), 7)
+	}
+
+	testCases := []struct {
+		name     string
+		query    merge_intervals.Interval
+		expected string
+	}{
+		{
+			name:     "Case 1",
+			query:    merge_intervals.Closed(6, 7),
+			expected: "[[5, 20]]",
+		},
+		{
+			name:     "Case 2",
+			query:    merge_intervals.Closed(30, 30),
+			expected: "[[10, 30] [30, 40]]",
+		},
+		{
+			name:     "Case 3",
+			query:    merge_intervals.Open(30, 35),
+			expected: "[[30, 40]]",
+		},
+		{
+			name:     "Case 4",
+			query:    merge_intervals.Closed(41, 50),
+			expected: "[]",
+		},
+		{
+			name:     "Case 5",
+			query:    merge_intervals.ClosedOpen(0, 13),
+			expected: "[[5, 20] [10, 30] [12, 15] [12, 15]]",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fmt.Sprintf("%v", tree.Overlapping(test.query)); got != test.expected {
+				t.Errorf("Overlapping(%v) = %v, want %v", test.query, got, test.expected)
+			}
+		})
+	}
+
+	if !tree.Delete(merge_intervals.Closed(12, 15)) || !tree.Delete(merge_intervals.Closed(5, 20)) {
+		t.Errorf("Delete of an existing interval returned false")
+	}
+	if tree.Delete(merge_intervals.ClosedOpen(5, 20)) {
+		t.Errorf("Delete of a missing interval returned true")
+	}
+	if got := fmt.Sprintf("%v", slices.Collect(tree.All())); got != "[[10, 30] [12, 15] [15, 20] [17, 19] [30, 40]]" {
+		t.Errorf("All() = %v, want %v", got, "[[10, 30] [12, 15] [15, 20] [17, 19] [30, 40]]")
+	}
+
+	if got, ok := tree.AnyContaining(35); !ok || got != merge_intervals.Closed(30, 40) {
+		t.Errorf("AnyContaining(35) = %v, %v, want %v", got, ok, merge_intervals.Closed(30, 40))
+	}
+	if got, ok := tree.AnyContaining(7); ok {
+		t.Errorf("AnyContaining(7) = %v, want none", got)
+	}
+}
+
+func TestIntervalTreeMatchesSliceFunctions(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	tree := merge_intervals.NewIntervalTree()
+	var intervals [][]int
+
+	for op := 0; op < 3000; op++ {
+		if len(intervals) > 0 && rng.Intn(3) == 0 {
+			// delete a random interval
+			i := rng.Intn(len(intervals))
+			if !tree.Delete(merge_intervals.Closed(intervals[i][0], intervals[i][1])) {
+				t.Fatalf("Delete(%v) = false, want true", intervals[i])
+			}
+			intervals = slices.Delete(intervals, i, i+1)
+		} else {
+			start := rng.Intn(100)
+			interval := []int{start, start + rng.Intn(10)}
+			tree.Insert(merge_intervals.Closed(interval[0], interval[1]))
+			intervals = append(intervals, interval)
+		}
+
+		if tree.Len() != len(intervals) {
+			t.Fatalf("Len() = %d, want %d", tree.Len(), len(intervals))
+		}
+
+		// the tree iterates in sorted order, so merging it must match merging the unsorted slice
+		var sorted [][]int
+		for interval := range tree.All() {
+			sorted = append(sorted, []int{interval.Start, interval.End})
+		}
+		if !slices.IsSortedFunc(sorted, slices.Compare) {
+			t.Fatalf("All() = %v is not sorted", sorted)
+		}
+		if got, want := merge_intervals.MergeIntervals(sorted), merge_intervals.MergeIntervals(intervals); !reflect.DeepEqual(got, want) {
+			t.Fatalf("MergeIntervals(All()) = %v, want %v", got, want)
+		}
+
+		// overlap queries match a linear scan
+		start := rng.Intn(110)
+		query := merge_intervals.Closed(start, start+rng.Intn(5))
+		var expected [][]int
+		for _, interval := range sorted {
+			if interval[0] <= query.End && query.Start <= interval[1] {
+				expected = append(expected, interval)
+			}
+		}
+		var got [][]int
+		for _, interval := range tree.Overlapping(query) {
+			got = append(got, []int{interval.Start, interval.End})
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Overlapping(%v) = %v, want %v", query, got, expected)
+		}
+
+		// point queries match the merged intervals
+		p := rng.Intn(110)
+		contained := merge_intervals.NewIntervalSet(fromPairs(intervals)...).Contains(p)
+		if found, ok := tree.AnyContaining(p); ok != contained || (ok && !found.Contains(p)) {
+			t.Fatalf("AnyContaining(%d) = %v, %v, want %v", p, found, ok, contained)
+		}
+	}
+}
+
+func fromPairs(pairs [][]int) []merge_intervals.Interval {
+	intervals := make([]merge_intervals.Interval, len(pairs))
+	for i, pair := range pairs {
+		intervals[i] = merge_intervals.Closed(pair[0], pair[1])
+	}
+	return intervals
+}
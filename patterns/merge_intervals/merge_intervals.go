@@ -2,6 +2,8 @@ package merge_intervals
 
 import (
 	"sort"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
 )
 
 // The merge intervals pattern deals with problems involving overlapping intervals.
@@ -59,77 +61,273 @@ func LeastTime(tasks []byte, n int) int {
 	return len(tasks) + idleTime
 }
 
+// TaskSlot is one time unit of a task schedule, it either runs a task or stays idle
+type TaskSlot struct {
+	Task byte
+	Idle bool
+}
+
+// String returns the task of the slot or "idle"
+func (s TaskSlot) String() string {
+	if s.Idle {
+		return "idle"
+	}
+	return string(s.Task)
+}
+
+// TaskOptions configures ScheduleTasksWithOptions
+type TaskOptions struct {
+	// Cooldown is the number of time units between identical tasks
+	Cooldown int
+	// Cooldowns overrides Cooldown for specific tasks
+	Cooldowns map[byte]int
+	// Priorities decides which task runs first when several ready tasks have the same remaining count,
+	// the higher priority runs first and tasks without priority have priority 0
+	Priorities map[byte]int
+}
+
+// ScheduleTasks returns the order in which tasks are processed with cooling down period n between identical task,
+// including the idle time units. The schedule always has the length returned by LeastTime.
+func ScheduleTasks(tasks []byte, n int) []TaskSlot {
+	return ScheduleTasksWithOptions(tasks, TaskOptions{Cooldown: n})
+}
+
+// ScheduleTasksWithOptions returns the order in which tasks are processed, including the idle time units.
+// In every time unit the ready task with the most remaining runs is processed, ties are broken by priority and then by task.
+// With the same cooldown for every task this greedy schedule is the shortest possible one.
+// This solution has time complexity of O(t log k) and space complexity of O(k),
+// where t is the length of the schedule and k is the number of distinct tasks.
+func ScheduleTasksWithOptions(tasks []byte, options TaskOptions) []TaskSlot {
+	// first find the frequency of each task
+	taskFreqs := make(map[byte]int)
+	for _, task := range tasks {
+		taskFreqs[task]++
+	}
+
+	type taskState struct {
+		task      byte
+		remaining int
+		priority  int
+		readyAt   int
+	}
+	cooldown := func(task byte) int {
+		if c, ok := options.Cooldowns[task]; ok {
+			return max(c, 0)
+		}
+		return max(options.Cooldown, 0)
+	}
+
+	// ready tasks are ordered by remaining count, priority and task, cooling down tasks by the time they are ready again
+	ready := structs.NewHeap(func(a, b taskState) bool {
+		if a.remaining != b.remaining {
+			return a.remaining > b.remaining
+		}
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		return a.task < b.task
+	})
+	coolingDown := structs.NewHeap(func(a, b taskState) bool {
+		return a.readyAt < b.readyAt || (a.readyAt == b.readyAt && a.task < b.task)
+	})
+	for task, freq := range taskFreqs {
+		ready.Push(taskState{task: task, remaining: freq, priority: options.Priorities[task]})
+	}
+
+	schedule := make([]TaskSlot, 0, len(tasks))
+	for time := 0; !ready.Empty() || !coolingDown.Empty(); time++ {
+		// move the tasks whose cooling down period is over back to the ready tasks
+		for top, ok := coolingDown.Peek(); ok && top.readyAt <= time; top, ok = coolingDown.Peek() {
+			ready.Push(coolingDown.Pop())
+		}
+
+		// stay idle if every remaining task is still cooling down
+		if ready.Empty() {
+			schedule = append(schedule, TaskSlot{Idle: true})
+			continue
+		}
+
+		// process the chosen task and let it cool down if it has to run again
+		current := ready.Pop()
+		schedule = append(schedule, TaskSlot{Task: current.task})
+		current.remaining--
+		if current.remaining > 0 {
+			current.readyAt = time + cooldown(current.task) + 1
+			coolingDown.Push(current)
+		}
+	}
+
+	return schedule
+}
+
 // MergeIntervals merge the overlapping intervals so the result will consist of non-overlapping intervals.
+// The intervals are closed and don't need to be sorted, it is an adapter over IntervalSet.
 func MergeIntervals(intervals [][]int) [][]int {
 	if len(intervals) == 0 {
 		return nil
 	}
 
+	return toPairs(NewIntervalSet(fromPairs(intervals)...).Intervals())
+}
+
+// InsertInterval inserts a new interval into an existing intervals
+// The result will be sorted and non-overlapping, the given intervals are not modified.
+// It is an adapter over IntervalSet.
+func InsertInterval(existingIntervals [][]int, newInterval []int) [][]int {
+	set := NewIntervalSet(fromPairs(existingIntervals)...)
+	set.Add(Closed(newInterval[0], newInterval[1]))
+	return toPairs(set.Intervals())
+}
+
+// fromPairs converts [start, end] pairs into closed intervals
+func fromPairs(pairs [][]int) []Interval {
+	intervals := make([]Interval, len(pairs))
+	for i, pair := range pairs {
+		intervals[i] = Closed(pair[0], pair[1])
+	}
+	return intervals
+}
+
+// toPairs converts intervals into [start, end] pairs
+func toPairs(intervals []Interval) [][]int {
+	pairs := make([][]int, len(intervals))
+	for i, interval := range intervals {
+		pairs[i] = []int{interval.Start, interval.End}
+	}
+	return pairs
+}
+
+// EmployeeFreeTime returns the common free time of all employees, given the sorted working intervals of each employee.
+// Uses a min heap to walk through the k schedules in order of start time, like a k-way merge,
+// and reports every gap between the end of the merged working time and the next start.
+// This solution has time complexity of O(n log k) and space complexity of O(k),
+// where n is the number of intervals and k is the number of employees.
+func EmployeeFreeTime(schedules [][][]int) [][]int {
+	type scheduleElement struct {
+		start         int
+		employee      int
+		intervalIndex int
+	}
+
+	// push the first interval of every employee into the min heap ordered by start time
+	minHeap := structs.NewHeap(func(a, b scheduleElement) bool {
+		return a.start < b.start
+	})
+	for employee, schedule := range schedules {
+		if len(schedule) > 0 {
+			minHeap.Push(scheduleElement{start: schedule[0][0], employee: employee})
+		}
+	}
+	if minHeap.Empty() {
+		return nil
+	}
+
+	top, _ := minHeap.Peek()
+	previousEnd := schedules[top.employee][top.intervalIndex][1]
 	var result [][]int
-	result = append(result, intervals[0])
-
-	for i := 1; i < len(intervals); i++ {
-		lastAddedInterval := result[len(result)-1]
-		currentStart := intervals[i][0]
-		currentEnd := intervals[i][1]
-		prevEnd := lastAddedInterval[1]
-
-		// two intervals intersect
-		if currentStart <= prevEnd {
-			// if overlapping - update the end time of the last interval
-			if currentEnd > prevEnd {
-				lastAddedInterval[1] = currentEnd
-			} else {
-				// completely inside, do nothing
-			}
-		} else {
-			// two intervals not intersect at all
-			result = append(result, intervals[i])
+	for !minHeap.Empty() {
+		// pop the interval with the earliest start
+		current := minHeap.Pop()
+		interval := schedules[current.employee][current.intervalIndex]
+
+		// if it starts after every interval so far ended, the time in between is free for everyone
+		if interval[0] > previousEnd {
+			result = append(result, []int{previousEnd, interval[0]})
+		}
+		previousEnd = max(previousEnd, interval[1])
+
+		// push the next interval of the same employee
+		if next := current.intervalIndex + 1; next < len(schedules[current.employee]) {
+			minHeap.Push(scheduleElement{start: schedules[current.employee][next][0], employee: current.employee, intervalIndex: next})
 		}
 	}
 
 	return result
 }
 
-// InsertInterval inserts a new interval into an existing intervals
-// The result will be sorted and non-overlapping
-func InsertInterval(existingIntervals [][]int, newInterval []int) [][]int {
-	newStart, newEnd := newInterval[0], newInterval[1]
-	i, n := 0, len(existingIntervals)
+// IntervalIntersection returns the intersection of two lists of sorted and non-overlapping closed intervals.
+// Uses two pointers, one for each list, and moves forward the one whose interval ends first.
+// This solution has time complexity of O(n + m) and space complexity of O(1) besides the result.
+func IntervalIntersection(first [][]int, second [][]int) [][]int {
 	var result [][]int
+	i, j := 0, 0
+	for i < len(first) && j < len(second) {
+		// the intersection starts at the later start and ends at the earlier end
+		start := max(first[i][0], second[j][0])
+		end := min(first[i][1], second[j][1])
+		if start <= end {
+			result = append(result, []int{start, end})
+		}
 
-	// insert existing intervals which have start time less than or equal to the new interval start time
-	for i < n && existingIntervals[i][0] <= newStart {
-		result = append(result, existingIntervals[i])
-		i++
+		// the interval which ends first can't intersect anything else
+		if first[i][1] < second[j][1] {
+			i++
+		} else {
+			j++
+		}
 	}
 
-	// insert the new interval
-	// if the result is still empty or the new interval start time is greater than the last interval end time (not overlap), then append the new interval
-	// otherwise, if the new interval end time is greater than the last interval end time (not completely inside), then update the last interval end time
-	if len(result) == 0 || newStart > result[len(result)-1][1] {
-		result = append(result, []int{newStart, newEnd})
-	} else {
-		if newEnd > result[len(result)-1][1] {
-			result[len(result)-1][1] = newEnd
+	return result
+}
+
+// CanAttendAllMeetings checks if a person can attend all meetings, i.e. no two meetings overlap.
+// A meeting may start at the same time the previous one ends. The given intervals are not modified.
+// This solution has time complexity of O(n log n) and space complexity of O(n).
+func CanAttendAllMeetings(intervals [][]int) bool {
+	sorted := sortedByStart(intervals)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i][0] < sorted[i-1][1] {
+			return false
 		}
 	}
+	return true
+}
 
-	for i < n {
-		lastIndex := len(result) - 1
-		// two intervals intersect
-		if existingIntervals[i][0] <= result[lastIndex][1] {
-			// if two intervals overlapping - update the end time of the last interval
-			if existingIntervals[i][1] > result[lastIndex][1] {
-				result[lastIndex][1] = existingIntervals[i][1]
-			}
-		} else {
-			// if the new interval is not inserted, then append it to the end of the result
-			result = append(result, existingIntervals[i])
+// MinMeetingRooms returns the minimum number of rooms needed to hold all meetings.
+// Uses a min heap of end times, a room is reused when its meeting ends by the time the next meeting starts.
+// This solution has time complexity of O(n log n) and space complexity of O(n).
+func MinMeetingRooms(intervals [][]int) int {
+	rooms := structs.NewMinHeap[int]()
+	for _, interval := range sortedByStart(intervals) {
+		// reuse the room which becomes free the earliest if it is free already
+		if end, ok := rooms.Peek(); ok && end <= interval[0] {
+			rooms.Pop()
 		}
-
-		i++
+		rooms.Push(interval[1])
 	}
+	return rooms.Len()
+}
 
+// RemoveCoveredIntervals removes every interval covered by another interval and returns the remaining intervals sorted by start.
+// An interval [a, b] is covered by [c, d] if c <= a and b <= d. The given intervals are not modified.
+// This solution has time complexity of O(n log n) and space complexity of O(n).
+func RemoveCoveredIntervals(intervals [][]int) [][]int {
+	// sort by start ascending and end descending, so an interval can only be covered by one before it
+	sorted := make([][]int, len(intervals))
+	copy(sorted, intervals)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i][0] < sorted[j][0] || (sorted[i][0] == sorted[j][0] && sorted[i][1] > sorted[j][1])
+	})
+
+	var result [][]int
+	maxEnd := 0
+	for i, interval := range sorted {
+		// the interval is covered if an interval starting before it ends after it
+		if i > 0 && interval[1] <= maxEnd {
+			continue
+		}
+		result = append(result, interval)
+		maxEnd = interval[1]
+	}
 	return result
 }
+
+// sortedByStart returns a copy of the intervals sorted by start time
+func sortedByStart(intervals [][]int) [][]int {
+	sorted := make([][]int, len(intervals))
+	copy(sorted, intervals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i][0] < sorted[j][0]
+	})
+	return sorted
+}
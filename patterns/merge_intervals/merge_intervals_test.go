@@ -2,6 +2,8 @@ package merge_intervals_test
 
 import (
 	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/adyanf/coding-patterns-dsa/patterns/merge_intervals"
@@ -87,6 +89,11 @@ func TestMergeIntervals(t *testing.T) {
 			intervals: [][]int{{1, 2}, {3, 4}, {8, 8}},
 			expected:  [][]int{{1, 2}, {3, 4}, {8, 8}},
 		},
+		{
+			name:      "Case 6",
+			intervals: [][]int{{11, 15}, {6, 8}, {1, 5}, {4, 6}},
+			expected:  [][]int{{1, 8}, {11, 15}},
+		},
 	}
 
 	for _, test := range testCases {
@@ -140,10 +147,336 @@ func TestInsertInterval(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
+			existing := fmt.Sprintf("%v", test.existing)
 			result := merge_intervals.InsertInterval(test.existing, test.new)
 			if fmt.Sprintf("%v", result) != fmt.Sprintf("%v", test.expected) {
 				t.Errorf("InsertInterval(%v, %v) = %v, want %v", test.existing, test.new, result, test.expected)
 			}
+			if fmt.Sprintf("%v", test.existing) != existing {
+				t.Errorf("InsertInterval modified its input from %v to %v", existing, test.existing)
+			}
+		})
+	}
+}
+
+// formatSchedule renders the schedule with one character per time unit and '_' for idle time units
+func formatSchedule(schedule []merge_intervals.TaskSlot) string {
+	var sb strings.Builder
+	for _, slot := range schedule {
+		if slot.Idle {
+			sb.WriteByte('_')
+		} else {
+			sb.WriteByte(slot.Task)
+		}
+	}
+	return sb.String()
+}
+
+func TestScheduleTasksWithOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tasks    []byte
+		options  merge_intervals.TaskOptions
+		expected string
+	}{
+		{
+			name:     "Case 1",
+			tasks:    []byte{'A', 'A', 'B', 'B'},
+			options:  merge_intervals.TaskOptions{Cooldown: 2},
+			expected: "AB_AB",
+		},
+		{
+			name:     "Case 2",
+			tasks:    []byte{'A', 'A', 'A', 'B', 'B', 'C', 'C'},
+			options:  merge_intervals.TaskOptions{Cooldown: 1},
+			expected: "ABACABC",
+		},
+		{
+			name:     "Case 3",
+			tasks:    []byte{'A', 'A', 'B', 'B'},
+			options:  merge_intervals.TaskOptions{Cooldown: 2, Priorities: map[byte]int{'B': 1}},
+			expected: "BA_BA",
+		},
+		{
+			name:     "Case 4",
+			tasks:    []byte{'A', 'A', 'A', 'B'},
+			options:  merge_intervals.TaskOptions{Cooldowns: map[byte]int{'A': 2}},
+			expected: "AB_A__A",
+		},
+		{
+			name:     "Case 5",
+			tasks:    []byte{'S', 'I', 'V'},
+			options:  merge_intervals.TaskOptions{},
+			expected: "ISV",
+		},
+		{
+			name:     "Case 6",
+			tasks:    []byte{},
+			options:  merge_intervals.TaskOptions{Cooldown: 3},
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := formatSchedule(merge_intervals.ScheduleTasksWithOptions(test.tasks, test.options))
+			if result != test.expected {
+				t.Errorf("ScheduleTasksWithOptions(%s, %+v) = %v, want %v", test.tasks, test.options, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestScheduleTasksMatchesLeastTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+
+	for round := 0; round < 500; round++ {
+		tasks := make([]byte, 1+rng.Intn(30))
+		for i := range tasks {
+			tasks[i] = byte('A' + rng.Intn(1+rng.Intn(8)))
+		}
+		n := rng.Intn(6)
+
+		schedule := merge_intervals.ScheduleTasks(tasks, n)
+		if len(schedule) != merge_intervals.LeastTime(tasks, n) {
+			t.Fatalf("ScheduleTasks(%s, %d) = %v has length %d, want LeastTime %d",
+				tasks, n, formatSchedule(schedule), len(schedule), merge_intervals.LeastTime(tasks, n))
+		}
+
+		// every task runs as many times as given and identical tasks are at least n time units apart
+		counts := make(map[byte]int)
+		lastRun := make(map[byte]int)
+		for time, slot := range schedule {
+			if slot.Idle {
+				continue
+			}
+			if last, ok := lastRun[slot.Task]; ok && time-last <= n {
+				t.Fatalf("ScheduleTasks(%s, %d) = %v runs %c at %d and %d", tasks, n, formatSchedule(schedule), slot.Task, last, time)
+			}
+			lastRun[slot.Task] = time
+			counts[slot.Task]++
+		}
+		for _, task := range tasks {
+			counts[task]--
+		}
+		for task, count := range counts {
+			if count != 0 {
+				t.Fatalf("ScheduleTasks(%s, %d) = %v runs %c %d times too many", tasks, n, formatSchedule(schedule), task, count)
+			}
+		}
+	}
+}
+
+func TestEmployeeFreeTime(t *testing.T) {
+	testCases := []struct {
+		name      string
+		schedules [][][]int
+		expected  [][]int
+	}{
+		{
+			name:      "Case 1",
+			schedules: [][][]int{{{1, 2}, {5, 6}}, {{1, 3}}, {{4, 10}}},
+			expected:  [][]int{{3, 4}},
+		},
+		{
+			name:      "Case 2",
+			schedules: [][][]int{{{1, 3}, {6, 7}}, {{2, 4}}, {{2, 5}, {9, 12}}},
+			expected:  [][]int{{5, 6}, {7, 9}},
+		},
+		{
+			name:      "Case 3",
+			schedules: [][][]int{{{1, 3}, {5, 6}}, {{3, 5}}},
+			expected:  [][]int{},
+		},
+		{
+			name:      "Case 4",
+			schedules: [][][]int{{}, {{2, 3}, {7, 8}}, {}},
+			expected:  [][]int{{3, 7}},
+		},
+		{
+			name:      "Case 5",
+			schedules: [][][]int{},
+			expected:  [][]int{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := merge_intervals.EmployeeFreeTime(test.schedules)
+			if fmt.Sprintf("%v", result) != fmt.Sprintf("%v", test.expected) {
+				t.Errorf("EmployeeFreeTime(%v) = %v, want %v", test.schedules, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestIntervalIntersection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		first    [][]int
+		second   [][]int
+		expected [][]int
+	}{
+		{
+			name:     "Case 1",
+			first:    [][]int{{0, 2}, {5, 10}, {13, 23}, {24, 25}},
+			second:   [][]int{{1, 5}, {8, 12}, {15, 24}, {25, 26}},
+			expected: [][]int{{1, 2}, {5, 5}, {8, 10}, {15, 23}, {24, 24}, {25, 25}},
+		},
+		{
+			name:     "Case 2",
+			first:    [][]int{{1, 3}, {5, 7}, {9, 12}},
+			second:   [][]int{{5, 10}},
+			expected: [][]int{{5, 7}, {9, 10}},
+		},
+		{
+			name:     "Case 3",
+			first:    [][]int{{1, 3}, {5, 6}},
+			second:   [][]int{{7, 9}},
+			expected: [][]int{},
+		},
+		{
+			name:     "Case 4",
+			first:    [][]int{},
+			second:   [][]int{{1, 2}},
+			expected: [][]int{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := merge_intervals.IntervalIntersection(test.first, test.second)
+			if fmt.Sprintf("%v", result) != fmt.Sprintf("%v", test.expected) {
+				t.Errorf("IntervalIntersection(%v, %v) = %v, want %v", test.first, test.second, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestCanAttendAllMeetings(t *testing.T) {
+	testCases := []struct {
+		name      string
+		intervals [][]int
+		expected  bool
+	}{
+		{
+			name:      "Case 1",
+			intervals: [][]int{{0, 30}, {5, 10}, {15, 20}},
+			expected:  false,
+		},
+		{
+			name:      "Case 2",
+			intervals: [][]int{{7, 10}, {2, 4}},
+			expected:  true,
+		},
+		{
+			name:      "Case 3",
+			intervals: [][]int{{1, 5}, {5, 8}, {8, 9}},
+			expected:  true,
+		},
+		{
+			name:      "Case 4",
+			intervals: [][]int{},
+			expected:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := merge_intervals.CanAttendAllMeetings(test.intervals)
+			if result != test.expected {
+				t.Errorf("CanAttendAllMeetings(%v) = %v, want %v", test.intervals, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestMinMeetingRooms(t *testing.T) {
+	testCases := []struct {
+		name      string
+		intervals [][]int
+		expected  int
+	}{
+		{
+			name:      "Case 1",
+			intervals: [][]int{{0, 30}, {5, 10}, {15, 20}},
+			expected:  2,
+		},
+		{
+			name:      "Case 2",
+			intervals: [][]int{{7, 10}, {2, 4}},
+			expected:  1,
+		},
+		{
+			name:      "Case 3",
+			intervals: [][]int{{1, 4}, {2, 5}, {3, 6}, {4, 7}},
+			expected:  3,
+		},
+		{
+			name:      "Case 4",
+			intervals: [][]int{{1, 10}, {2, 3}, {3, 4}, {4, 5}},
+			expected:  2,
+		},
+		{
+			name:      "Case 5",
+			intervals: [][]int{},
+			expected:  0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := merge_intervals.MinMeetingRooms(test.intervals)
+			if result != test.expected {
+				t.Errorf("MinMeetingRooms(%v) = %v, want %v", test.intervals, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveCoveredIntervals(t *testing.T) {
+	testCases := []struct {
+		name      string
+		intervals [][]int
+		expected  [][]int
+	}{
+		{
+			name:      "Case 1",
+			intervals: [][]int{{1, 4}, {3, 6}, {2, 8}},
+			expected:  [][]int{{1, 4}, {2, 8}},
+		},
+		{
+			name:      "Case 2",
+			intervals: [][]int{{1, 4}, {2, 3}},
+			expected:  [][]int{{1, 4}},
+		},
+		{
+			name:      "Case 3",
+			intervals: [][]int{{1, 2}, {1, 4}, {3, 4}},
+			expected:  [][]int{{1, 4}},
+		},
+		{
+			name:      "Case 4",
+			intervals: [][]int{{3, 10}, {4, 10}, {5, 11}},
+			expected:  [][]int{{3, 10}, {5, 11}},
+		},
+		{
+			name:      "Case 5",
+			intervals: [][]int{{2, 5}, {2, 5}},
+			expected:  [][]int{{2, 5}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			original := fmt.Sprintf("%v", test.intervals)
+			result := merge_intervals.RemoveCoveredIntervals(test.intervals)
+			if fmt.Sprintf("%v", result) != fmt.Sprintf("%v", test.expected) {
+				t.Errorf("RemoveCoveredIntervals(%v) = %v, want %v", test.intervals, result, test.expected)
+			}
+			if fmt.Sprintf("%v", test.intervals) != original {
+				t.Errorf("RemoveCoveredIntervals modified its input to %v, want %v", test.intervals, original)
+			}
 		})
 	}
 }
@@ -1,6 +1,6 @@
 package modified_binary_search
 
-import "math"
+import "cmp"
 
 // The modified binary search pattern is an extension of the traditional binary search algorithm and can be applied to a wide range of problems.
 // Before we delve into the modified version, let’s first recap the classic binary search algorithm.
@@ -30,111 +30,213 @@ import "math"
 
 // SingleNonDuplicate returns the element that appears only once in a sorted array where every other element appears twice.
 func SingleNonDuplicate(nums []int) int {
-	// set starting point as first and last index of array
-	start, end := 0, len(nums)-1
-
-	// continue iterate as long as start < end
-	for start < end {
-		// calculate the middle of start and end
-		middle := start + (end-start)/2
-
-		// if the middle index is odd, then subtract it by one
-		// it is to make sure that middle always in the start of the duplicate elements
-		if middle%2 == 1 {
-			middle -= 1
-		}
-
-		// if the nums[middle] == nums[middle+1], it means that all elements up to this point were in pairs,
-		// and the non duplicate element must appear after middle. Therefore, move the start pointer toward the end.
-		// move the start as middle+2 (next occurrence of duplicate elements, because the middle+1 already equal middle).
-		//
-		// if the nums[middle] != nums[middle+1], it means that the non duplicate element must have appeared before middle.
-		// therefore, move the end pointer toward the start.
-		// move the end as middle (because the middle can be the non duplicate element).
-		if nums[middle] == nums[middle+1] {
-			start = middle + 2
-		} else {
-			end = middle
-		}
-	}
+	// split the array into pairs nums[2p], nums[2p+1], so every pair starts at an even index.
+	// the pairs before the non duplicate element start at an even index and hold two equal elements,
+	// because every duplicate there is preceded by full pairs only.
+	// from the non duplicate element on every pair is broken, because the non duplicate element shifts the duplicates by one,
+	// so they start at an odd index and each pair holds the second element of one duplicate and the first element of the next.
+	//
+	// this makes "the pair p is broken" a predicate which is false up to some pair and true from it on,
+	// so binary search the first broken pair over the len(nums)/2 full pairs.
+	// if no full pair is broken, the non duplicate element is the last element, which is left out of the pairs,
+	// and FirstTrue returns len(nums)/2 which is exactly the pair it would start.
+	pair := FirstTrue(0, len(nums)/2, func(p int) bool {
+		return nums[2*p] != nums[2*p+1]
+	})
 
-	// return the nums[start] because the start will point to non duplicate element
-	return nums[start]
+	// the non duplicate element is the first element of the first broken pair
+	return nums[2*pair]
 }
 
 // FindClosestElement returns k elements closest to target, including target if found.
+// When two elements are equally close, the smaller one is preferred.
 func FindClosestElement(nums []int, k int, target int) []int {
-	// if the length of nums is equal to k return nums, because the target is irrelevant
-	if len(nums) == k {
-		return nums
+	// the result is a window nums[i:i+k], so instead of searching target binary search the start of the window,
+	// every start i is in the range [0, len(nums)-k].
+	//
+	// compare the first element of the window starting at i with the first element after it, nums[i+k]:
+	// 	- if nums[i+k] is closer to target than nums[i], the window starting at i+1 is better, so the start must move right
+	// 	- otherwise nums[i] is at least as close as nums[i+k], and since nums is sorted every window further right is not better
+	// the second condition is false up to the best start and true from it on, so the best start is the first i where it holds.
+	// target-nums[i] <= nums[i+k]-target compares the distances without absolute values, because nums[i] <= nums[i+k],
+	// and on a tie it keeps nums[i], which prefers the smaller element.
+	start := FirstTrue(0, len(nums)-k, func(i int) bool {
+		return target-nums[i] <= nums[i+k]-target
+	})
+
+	// return the window slice of nums
+	return nums[start : start+k]
+}
+
+// RotatedBinarySearchIterative search a target in array nums, which might be rotated, with iterative style
+func RotatedBinarySearchIterative(nums []int, target int) int {
+	// there is nothing to search in an empty array
+	if len(nums) == 0 {
+		return -1
 	}
 
-	// if the target is less than or equal to the first element of nums
-	// then return the first k elements of nums
-	if target <= nums[0] {
-		return nums[:k]
+	// a rotated array consists of two sorted parts, and every element of the first part is >= nums[0]
+	// while every element of the second part is < nums[0].
+	// so "nums[i] < nums[0]" is false over the first part and true over the second part,
+	// and binary searching the first index where it holds finds the rotation point.
+	// it is len(nums) if the array is not rotated, then the first part is the whole array.
+	rotation := FirstTrue(1, len(nums), func(i int) bool {
+		return nums[i] < nums[0]
+	})
+
+	// the target can only be in the part whose values range includes it:
+	// 	- if the target is >= nums[0], search the first part nums[0:rotation]
+	// 	- otherwise, search the second part nums[rotation:]
+	start, end := 0, rotation
+	if target < nums[0] {
+		start, end = rotation, len(nums)
 	}
 
-	// if the target is greater than or equal to the last element of nums
-	// then return the last k elements of nums
-	if target >= nums[len(nums)-1] {
-		return nums[len(nums)-k:]
+	// binary search the target in the sorted part, the lower bound is the index of the target if it is there
+	if index := start + LowerBound(nums[start:end], target); index < end && nums[index] == target {
+		return index
 	}
 
-	// init the start and end pointers based on nums
-	start, end := 0, len(nums)-1
+	// if the lower bound doesn't hold the target, then we didn't found the target
+	return -1
+}
 
-	// find the index of the target or the closest element to target
-	var closest int
-	// iterate as long as the start pointer < end pointer
-	for start <= end {
-		closest = start + (end-start)/2
+// RotatedBinarySearchRecursive search a target in array nums, which might be rotated, with recursive style
+func RotatedBinarySearchRecursive(nums []int, target int) int {
+	return binarySearchParametered(nums, 0, len(nums)-1, target)
+}
 
-		// break if the target index already found
-		if nums[closest] == target {
-			break
+// binarySearchParametered search a target in an array but limited by the start and the end indexes
+// in this example the array might be rotated
+func binarySearchParametered(nums []int, start int, end int, target int) int {
+	// base case for recursive function, if start > end then we didn't found the target
+	if start > end {
+		return -1
+	}
+
+	// calculate the mid index based on the search parameter
+	mid := start + (end-start)/2
+
+	// if the mid index contain the target return the index immediately
+	if nums[mid] == target {
+		return mid
+	}
+
+	// if the array is sorted from start index to mid index
+	// check if the target is less than mid index and larger or equal to start index
+	// 	- if yes, then search the first half of the search parameter
+	// 	- if no, then search the latter half of the search parameter
+	if nums[start] <= nums[mid] {
+		if target < nums[mid] && target >= nums[start] {
+			return binarySearchParametered(nums, start, mid-1, target)
 		}
+		return binarySearchParametered(nums, mid+1, end, target)
+	}
 
-		// otherwise, move the start pointer or end pointer to the closest
-		if nums[closest] < target {
-			start = closest + 1
+	// here, the array is rotated (not sorted) in between start index to mid index
+	// check if the target is larger than mid index and less or equal to end index
+	// 	- if yes, then search the latter half of the search parameter
+	// 	- if no, then search the first half of the search parameter
+	if nums[mid] < target && target <= nums[end] {
+		return binarySearchParametered(nums, mid+1, end, target)
+	}
+	return binarySearchParametered(nums, start, mid-1, target)
+}
+
+// Integer is a constraint for every integer type
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// SearchAnswer returns the smallest x in the range [lo, hi] for which pred(x) is true, binary searching on the answer instead of an array.
+// pred must be monotonic on the range: once it is true for some x, it is true for every greater x.
+// The second return value is false if pred is false on the whole range or the range is empty.
+// This solution has time complexity of O(log(hi - lo)) calls of pred and space complexity of O(1).
+func SearchAnswer[T Integer](lo T, hi T, pred func(T) bool) (T, bool) {
+	if lo > hi {
+		return lo, false
+	}
+
+	for lo < hi {
+		// the floor of the average of lo and hi, computed without overflowing
+		mid := (lo & hi) + (lo^hi)>>1
+
+		// if pred holds at mid, the answer is mid or before it, otherwise the answer is after mid
+		if pred(mid) {
+			hi = mid
 		} else {
-			end = closest - 1
+			lo = mid + 1
 		}
 	}
-	// if start > end, then closest is the index where the target should be inserted
-	if start > end {
-		closest = start
-	}
-
-	// set the window left based on closest index
-	windowLeft := closest - 1
-	// set the window right based on window left + 1
-	windowRight := windowLeft + 1
-	// iterate as long as the windows size is less than k
-	for (windowRight - windowLeft - 1) < k {
-		// if window left already less than 0, then increment window right
-		if windowLeft < 0 {
-			windowRight++
-			continue
+
+	// lo is the only candidate left, it is the answer only if pred holds there
+	return lo, pred(lo)
+}
+
+// FirstTrue returns the smallest index i in the range [lo, hi) for which pred(i) is true, or hi if there is none.
+// pred must be monotonic on the range: once it is true for some i, it is true for every greater i.
+// This solution has time complexity of O(log(hi - lo)) calls of pred and space complexity of O(1).
+func FirstTrue(lo int, hi int, pred func(int) bool) int {
+	if index, found := SearchAnswer(lo, hi-1, pred); found {
+		return index
+	}
+	return max(lo, hi)
+}
+
+// LowerBound returns the index of the first element of the sorted nums which is not less than target,
+// or len(nums) if every element is less than target. It is the position where target would be inserted before its equal elements.
+// This solution has time complexity of O(log n) and space complexity of O(1).
+func LowerBound[T cmp.Ordered](nums []T, target T) int {
+	return FirstTrue(0, len(nums), func(i int) bool {
+		return nums[i] >= target
+	})
+}
+
+// UpperBound returns the index of the first element of the sorted nums which is greater than target,
+// or len(nums) if there is none. It is the position where target would be inserted after its equal elements.
+// This solution has time complexity of O(log n) and space complexity of O(1).
+func UpperBound[T cmp.Ordered](nums []T, target T) int {
+	return FirstTrue(0, len(nums), func(i int) bool {
+		return nums[i] > target
+	})
+}
+
+// EqualRange returns the range [lo, hi) of the elements of the sorted nums which are equal to target.
+// The range is empty, with lo == hi being the insertion position, if target is not in nums.
+// This solution has time complexity of O(log n) and space complexity of O(1).
+func EqualRange[T cmp.Ordered](nums []T, target T) (lo int, hi int) {
+	lo = LowerBound(nums, target)
+	return lo, lo + UpperBound(nums[lo:], target)
+}
+
+// BisectFloat returns the smallest x in the range [lo, hi] for which pred(x) is true, within a tolerance of eps.
+// pred must be monotonic on the range: once it is true for some x, it is true for every greater x.
+// The result is never below the true answer and at most eps above it. If pred is true nowhere before hi, hi is returned.
+// The search also stops once the floating point numbers can't be split anymore, so an eps of 0 is allowed.
+// This solution has time complexity of O(log((hi - lo) / eps)) calls of pred and space complexity of O(1).
+func BisectFloat(lo float64, hi float64, eps float64, pred func(float64) bool) float64 {
+	for hi-lo > eps {
+		mid := lo + (hi-lo)/2
+		// the range is too narrow to split further
+		if mid <= lo || mid >= hi {
+			break
 		}
 
-		// if window right already length of nums then decrement window Left
-		// if |nums[windowLeft]-target| <= |nums[windowRight]-target|, then decrement window left
-		// otherwise, increment window right
-		if windowRight == len(nums) || math.Abs(float64(nums[windowLeft]-target)) <= math.Abs(float64(nums[windowRight]-target)) {
-			windowLeft--
+		if pred(mid) {
+			hi = mid
 		} else {
-			windowRight++
+			lo = mid
 		}
 	}
-
-	// return the window slice of nums
-	return nums[windowLeft+1 : windowRight]
+	return hi
 }
 
-// RotatedBinarySearchIterative search a target in array nums, which might be rotated, with iterative style
-func RotatedBinarySearchIterative(nums []int, target int) int {
+// RotatedBinarySearchWithDuplicatesIterative search a target in array nums, which might be rotated and might contain duplicates, with iterative style.
+// It returns an index of the target or -1 if the target is not found.
+// When the start, middle and end elements are equal we can't tell which half is sorted, so both ends are shrunk by one,
+// which makes the worst case linear when most elements are equal.
+// This solution has time complexity of O(log n) on average and O(n) in the worst case, and space complexity of O(1).
+func RotatedBinarySearchWithDuplicatesIterative(nums []int, target int) int {
 	// init search parameter
 	start, end := 0, len(nums)-1
 	// keep iterating as long as start index is less than or equal end index
@@ -146,10 +248,15 @@ func RotatedBinarySearchIterative(nums []int, target int) int {
 			return mid
 		}
 
-		// if the array is sorted from start index to mid index
-		// check if the target is less than mid index and larger or equal to start index
-		// 	- if yes, then search the first half of the search parameter by end = mid - 1
-		// 	- if no, then search the latter half of the search parameter by start = mid + 1
+		// if the start, mid and end elements are equal, the target might be on either side,
+		// but neither start nor end is the target, so both can be dropped
+		if nums[start] == nums[mid] && nums[mid] == nums[end] {
+			start++
+			end--
+			continue
+		}
+
+		// if the array is sorted from start index to mid index, search the first half only if the target is in its range
 		if nums[start] <= nums[mid] {
 			if nums[start] <= target && target < nums[mid] {
 				end = mid - 1
@@ -159,10 +266,7 @@ func RotatedBinarySearchIterative(nums []int, target int) int {
 			continue
 		}
 
-		// here, array is sorted from mid index to end index
-		// check if the target is larger than mid index and less or equal to end index
-		// 	- if yes, then search the latter half of the search parameter by start = mid + 1
-		// 	- if no, then search the first half of the search parameter by end = mid - 1
+		// here, array is sorted from mid index to end index, search the latter half only if the target is in its range
 		if nums[mid] < target && target <= nums[end] {
 			start = mid + 1
 		} else {
@@ -174,14 +278,15 @@ func RotatedBinarySearchIterative(nums []int, target int) int {
 	return -1
 }
 
-// RotatedBinarySearchRecursive search a target in array nums, which might be rotated, with recursive style
-func RotatedBinarySearchRecursive(nums []int, target int) int {
-	return binarySearchParametered(nums, 0, len(nums)-1, target)
+// RotatedBinarySearchWithDuplicatesRecursive search a target in array nums, which might be rotated and might contain duplicates, with recursive style.
+// It returns an index of the target or -1 if the target is not found, see RotatedBinarySearchWithDuplicatesIterative.
+func RotatedBinarySearchWithDuplicatesRecursive(nums []int, target int) int {
+	return binarySearchWithDuplicatesParametered(nums, 0, len(nums)-1, target)
 }
 
-// binarySearchParametered search a target in an array but limited by the start and the end indexes
+// binarySearchWithDuplicatesParametered search a target in an array which might contain duplicates but limited by the start and the end indexes
 // in this example the array might be rotated
-func binarySearchParametered(nums []int, start int, end int, target int) int {
+func binarySearchWithDuplicatesParametered(nums []int, start int, end int, target int) int {
 	// base case for recursive function, if start > end then we didn't found the target
 	if start > end {
 		return -1
@@ -195,23 +300,259 @@ func binarySearchParametered(nums []int, start int, end int, target int) int {
 		return mid
 	}
 
-	// if the array is sorted from start index to mid index
-	// check if the target is less than mid index and larger or equal to start index
-	// 	- if yes, then search the first half of the search parameter
-	// 	- if no, then search the latter half of the search parameter
+	// if the start, mid and end elements are equal, drop both ends which are not the target
+	if nums[start] == nums[mid] && nums[mid] == nums[end] {
+		return binarySearchWithDuplicatesParametered(nums, start+1, end-1, target)
+	}
+
+	// if the array is sorted from start index to mid index, search the first half only if the target is in its range
 	if nums[start] <= nums[mid] {
-		if target < nums[mid] && target >= nums[start] {
-			return binarySearchParametered(nums, start, mid-1, target)
+		if nums[start] <= target && target < nums[mid] {
+			return binarySearchWithDuplicatesParametered(nums, start, mid-1, target)
 		}
-		return binarySearchParametered(nums, mid+1, end, target)
+		return binarySearchWithDuplicatesParametered(nums, mid+1, end, target)
 	}
 
-	// here, the array is rotated (not sorted) in between start index to mid index
-	// check if the target is larger than mid index and less or equal to end index
-	// 	- if yes, then search the latter half of the search parameter
-	// 	- if no, then search the first half of the search parameter
+	// here, the array is sorted from mid index to end index, search the latter half only if the target is in its range
 	if nums[mid] < target && target <= nums[end] {
-		return binarySearchParametered(nums, mid+1, end, target)
+		return binarySearchWithDuplicatesParametered(nums, mid+1, end, target)
 	}
-	return binarySearchParametered(nums, start, mid-1, target)
+	return binarySearchWithDuplicatesParametered(nums, start, mid-1, target)
+}
+
+// FindRotationPoint returns the index where the sorted array nums, which might be rotated and might contain duplicates, starts.
+// It is the index of the drop from the largest to the smallest element, rotating nums left by it gives back the sorted array.
+// It returns 0 if nums is not rotated or is empty.
+// This solution has time complexity of O(log n) on average and O(n) in the worst case, and space complexity of O(1).
+func FindRotationPoint(nums []int) int {
+	start, end := 0, len(nums)-1
+	for start < end {
+		mid := start + (end-start)/2
+
+		switch {
+		case nums[mid] > nums[end]:
+			// the drop from the largest to the smallest element is after mid
+			start = mid + 1
+		case nums[mid] < nums[end]:
+			// the part from mid to end is sorted, so the rotation point is mid or before it
+			end = mid
+		default:
+			// nums[mid] == nums[end] doesn't tell where the drop is, but end can be dropped
+			// unless the drop is right before it, then end is the rotation point
+			if nums[end-1] > nums[end] {
+				return end
+			}
+			end--
+		}
+	}
+
+	// the drop, if there is one, always stays between start and end, and start only moves once a drop is proven,
+	// so start is either the drop or 0 for an array which is not rotated
+	return start
+}
+
+// FindMinInRotated returns the smallest element of the sorted array nums, which might be rotated and might contain duplicates.
+// The second return value is false if nums is empty.
+// This solution has time complexity of O(log n) on average and O(n) in the worst case, and space complexity of O(1).
+func FindMinInRotated(nums []int) (int, bool) {
+	if len(nums) == 0 {
+		return 0, false
+	}
+	return nums[FindRotationPoint(nums)], true
+}
+
+// SearchRange returns the first and the last index of target in the sorted array nums, which might be rotated and might contain duplicates.
+// The occurrences are contiguous in the sorted array, so they either are contiguous in nums or wrap around its end,
+// in which case the first index is 0 and the last index is len(nums)-1. It returns -1, -1 if target is not found.
+// This solution has time complexity of O(log n) on average and O(n) in the worst case, and space complexity of O(1).
+func SearchRange(nums []int, target int) (first int, last int) {
+	n := len(nums)
+	rotation := FindRotationPoint(nums)
+
+	// search the sorted view of nums, where the i-th element is nums[(rotation+i)%n]
+	sorted := func(i int) int { return nums[(rotation+i)%n] }
+	lo := FirstTrue(0, n, func(i int) bool { return sorted(i) >= target })
+	hi := FirstTrue(lo, n, func(i int) bool { return sorted(i) > target })
+	if lo == hi {
+		return -1, -1
+	}
+
+	// map the range of the sorted view back to nums
+	first, last = (rotation+lo)%n, (rotation+hi-1)%n
+	if first > last {
+		return 0, n - 1
+	}
+	return first, last
+}
+
+// FindPeakElement returns the index of a peak element, an element strictly greater than its neighbors,
+// where adjacent elements are distinct and the elements outside of nums are treated as negative infinity.
+// It returns -1 if nums is empty.
+// This solution has time complexity of O(log n) and space complexity of O(1).
+func FindPeakElement(nums []int) int {
+	if len(nums) == 0 {
+		return -1
+	}
+
+	// walking uphill always ends at a peak, so the first index going downhill to its right neighbor is a peak.
+	// the last element is a peak if nums never goes downhill.
+	return FirstTrue(0, len(nums)-1, func(i int) bool {
+		return nums[i] > nums[i+1]
+	})
+}
+
+// FindPeakIn2DGrid returns the row and the column of a peak element of the grid, an element strictly greater than its
+// four neighbors, where adjacent elements are distinct and the elements outside of the grid are treated as negative infinity.
+// It returns -1, -1 if the grid is empty.
+// This solution has time complexity of O(n log m) and space complexity of O(1), where n is the number of rows and m is the number of columns.
+func FindPeakIn2DGrid(grid [][]int) (row int, column int) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return -1, -1
+	}
+
+	// maxRow returns the row of the largest element of the column, it is greater than its upper and lower neighbors
+	maxRow := func(column int) int {
+		row := 0
+		for r := range grid {
+			if grid[r][column] > grid[row][column] {
+				row = r
+			}
+		}
+		return row
+	}
+
+	// binary search the columns, keeping a column whose left neighbor is smaller than the maximum of the column
+	start, end := 0, len(grid[0])-1
+	for start < end {
+		mid := start + (end-start)/2
+		row := maxRow(mid)
+
+		// if the right neighbor of the maximum is larger, the maximum of the right columns is larger too, move right.
+		// otherwise the maximum of the mid column is a peak candidate, keep it as the end.
+		if grid[row][mid] < grid[row][mid+1] {
+			start = mid + 1
+		} else {
+			end = mid
+		}
+	}
+
+	return maxRow(start), start
+}
+
+// SearchSortedMatrix returns the row and the column of target in a matrix whose rows and columns are sorted in ascending order,
+// or -1, -1 if the target is not found.
+// It uses SearchSortedMatrixStaircase, which is linear in the size of both dimensions, while SearchSortedMatrixBinary
+// is faster for a matrix with few rows and many columns.
+// This solution has time complexity of O(n + m) and space complexity of O(1), where n is the number of rows and m is the number of columns.
+func SearchSortedMatrix(matrix [][]int, target int) (row int, column int) {
+	return SearchSortedMatrixStaircase(matrix, target)
+}
+
+// SearchSortedMatrixStaircase returns the row and the column of target in a matrix whose rows and columns are sorted in ascending order,
+// or -1, -1 if the target is not found.
+// Starts from the top right corner, every step either finds the target or drops a row which is too small or a column which is too large.
+// This solution has time complexity of O(n + m) and space complexity of O(1), where n is the number of rows and m is the number of columns.
+func SearchSortedMatrixStaircase(matrix [][]int, target int) (row int, column int) {
+	if len(matrix) == 0 {
+		return -1, -1
+	}
+
+	row, column = 0, len(matrix[0])-1
+	for row < len(matrix) && column >= 0 {
+		switch {
+		case matrix[row][column] == target:
+			return row, column
+		case matrix[row][column] > target:
+			// every element below in this column is larger too
+			column--
+		default:
+			// every element left in this row is smaller too
+			row++
+		}
+	}
+	return -1, -1
+}
+
+// SearchSortedMatrixBinary returns the row and the column of target in a matrix whose rows and columns are sorted in ascending order,
+// or -1, -1 if the target is not found.
+// Binary searches the target in every row whose range includes the target, the rows are narrowed down by binary searching the first column.
+// This solution has time complexity of O(n log m) and space complexity of O(1), where n is the number of rows and m is the number of columns.
+func SearchSortedMatrixBinary(matrix [][]int, target int) (row int, column int) {
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return -1, -1
+	}
+
+	// the rows starting after the target can't contain it
+	rows := FirstTrue(0, len(matrix), func(r int) bool {
+		return matrix[r][0] > target
+	})
+	for row = 0; row < rows; row++ {
+		// the rows ending before the target can't contain it
+		if matrix[row][len(matrix[row])-1] < target {
+			continue
+		}
+		if column = LowerBound(matrix[row], target); column < len(matrix[row]) && matrix[row][column] == target {
+			return row, column
+		}
+	}
+	return -1, -1
+}
+
+// SplitArrayLargestSum splits the non-negative nums into k non-empty contiguous subarrays and returns the smallest possible largest subarray sum.
+// It returns -1 if k is not between 1 and len(nums).
+// Binary searches on the largest sum, a largest sum is feasible if greedily filling the subarrays up to it needs at most k subarrays.
+// This solution has time complexity of O(n log(sum)) and space complexity of O(1).
+func SplitArrayLargestSum(nums []int, k int) int {
+	if k < 1 || k > len(nums) {
+		return -1
+	}
+
+	largest, sum := 0, 0
+	for _, num := range nums {
+		largest = max(largest, num)
+		sum += num
+	}
+
+	// the largest sum is at least the largest element and at most the sum of all elements, which is always feasible
+	answer, _ := SearchAnswer(largest, sum, func(limit int) bool {
+		return countGreedyGroups(nums, limit) <= k
+	})
+	return answer
+}
+
+// MinimumShipCapacity returns the least ship capacity that ships all packages within the given days,
+// where the packages are shipped in the given order and each day the ship is loaded with packages up to its capacity.
+// It returns -1 if days is not positive.
+// Binary searches on the capacity, a capacity is feasible if greedily loading the ship needs at most days days.
+// This solution has time complexity of O(n log(sum)) and space complexity of O(1).
+func MinimumShipCapacity(weights []int, days int) int {
+	if days < 1 {
+		return -1
+	}
+
+	heaviest, sum := 0, 0
+	for _, weight := range weights {
+		heaviest = max(heaviest, weight)
+		sum += weight
+	}
+
+	// the capacity is at least the heaviest package and at most the sum of all weights, which ships everything in a day
+	answer, _ := SearchAnswer(heaviest, sum, func(capacity int) bool {
+		return countGreedyGroups(weights, capacity) <= days
+	})
+	return answer
+}
+
+// countGreedyGroups returns the number of contiguous groups needed when every group is filled up to limit before starting the next one,
+// every element must not be larger than limit
+func countGreedyGroups(nums []int, limit int) int {
+	groups, current := 1, 0
+	for _, num := range nums {
+		if current+num > limit {
+			groups++
+			current = 0
+		}
+		current += num
+	}
+	return groups
 }
@@ -1,6 +1,9 @@
 package modified_binary_search_test
 
 import (
+	"math"
+	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/adyanf/coding-patterns-dsa/patterns/modified_binary_search"
@@ -212,3 +215,708 @@ func TestRotatedBinarySearchRecursive(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchAnswer(t *testing.T) {
+	tests := []struct {
+		name          string
+		lo            int
+		hi            int
+		pred          func(int) bool
+		expected      int
+		expectedFound bool
+	}{
+		{
+			name:          "Case 1",
+			lo:            0,
+			hi:            100,
+			pred:          func(x int) bool { return x*x >= 50 },
+			expected:      8,
+			expectedFound: true,
+		},
+		{
+			name:          "Case 2",
+			lo:            -10,
+			hi:            10,
+			pred:          func(x int) bool { return x >= -10 },
+			expected:      -10,
+			expectedFound: true,
+		},
+		{
+			name:          "Case 3",
+			lo:            1,
+			hi:            5,
+			pred:          func(x int) bool { return x > 5 },
+			expectedFound: false,
+		},
+		{
+			name:          "Case 4",
+			lo:            math.MinInt,
+			hi:            math.MaxInt,
+			pred:          func(x int) bool { return x >= math.MaxInt-1 },
+			expected:      math.MaxInt - 1,
+			expectedFound: true,
+		},
+		{
+			name:          "Case 5",
+			lo:            3,
+			hi:            2,
+			pred:          func(x int) bool { return true },
+			expectedFound: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, found := modified_binary_search.SearchAnswer(test.lo, test.hi, test.pred)
+			if found != test.expectedFound || (found && got != test.expected) {
+				t.Errorf("SearchAnswer(%d, %d) = %d, %v, want %d, %v", test.lo, test.hi, got, found, test.expected, test.expectedFound)
+			}
+		})
+	}
+
+	// unsigned ranges reaching the maximum value don't overflow either
+	if got, found := modified_binary_search.SearchAnswer(uint8(0), uint8(255), func(x uint8) bool { return x >= 200 }); !found || got != 200 {
+		t.Errorf("SearchAnswer(0, 255) = %d, %v, want %d, %v", got, found, 200, true)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name          string
+		nums          []int
+		target        int
+		expectedLower int
+		expectedUpper int
+	}{
+		{
+			name:          "Case 1",
+			nums:          []int{1, 2, 2, 2, 5, 7},
+			target:        2,
+			expectedLower: 1,
+			expectedUpper: 4,
+		},
+		{
+			name:          "Case 2",
+			nums:          []int{1, 2, 2, 2, 5, 7},
+			target:        4,
+			expectedLower: 4,
+			expectedUpper: 4,
+		},
+		{
+			name:          "Case 3",
+			nums:          []int{1, 2, 2, 2, 5, 7},
+			target:        0,
+			expectedLower: 0,
+			expectedUpper: 0,
+		},
+		{
+			name:          "Case 4",
+			nums:          []int{1, 2, 2, 2, 5, 7},
+			target:        7,
+			expectedLower: 5,
+			expectedUpper: 6,
+		},
+		{
+			name:          "Case 5",
+			nums:          []int{},
+			target:        3,
+			expectedLower: 0,
+			expectedUpper: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := modified_binary_search.LowerBound(test.nums, test.target); got != test.expectedLower {
+				t.Errorf("LowerBound(%v, %d) = %d, want %d", test.nums, test.target, got, test.expectedLower)
+			}
+			if got := modified_binary_search.UpperBound(test.nums, test.target); got != test.expectedUpper {
+				t.Errorf("UpperBound(%v, %d) = %d, want %d", test.nums, test.target, got, test.expectedUpper)
+			}
+			if lo, hi := modified_binary_search.EqualRange(test.nums, test.target); lo != test.expectedLower || hi != test.expectedUpper {
+				t.Errorf("EqualRange(%v, %d) = %d, %d, want %d, %d", test.nums, test.target, lo, hi, test.expectedLower, test.expectedUpper)
+			}
+		})
+	}
+
+	// the bounds work on any ordered type
+	words := []string{"apple", "banana", "banana", "cherry"}
+	if lo, hi := modified_binary_search.EqualRange(words, "banana"); lo != 1 || hi != 3 {
+		t.Errorf("EqualRange(%v, %q) = %d, %d, want %d, %d", words, "banana", lo, hi, 1, 3)
+	}
+}
+
+func TestBoundsMatchLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(18))
+
+	for round := 0; round < 1000; round++ {
+		nums := make([]int, rng.Intn(12))
+		for i := range nums {
+			nums[i] = rng.Intn(8)
+		}
+		slices.Sort(nums)
+		target := rng.Intn(10) - 1
+
+		lower, upper := len(nums), len(nums)
+		for i := len(nums) - 1; i >= 0; i-- {
+			if nums[i] >= target {
+				lower = i
+			}
+			if nums[i] > target {
+				upper = i
+			}
+		}
+
+		if lo, hi := modified_binary_search.EqualRange(nums, target); lo != lower || hi != upper {
+			t.Fatalf("EqualRange(%v, %d) = %d, %d, want %d, %d", nums, target, lo, hi, lower, upper)
+		}
+	}
+}
+
+func TestFirstTrue(t *testing.T) {
+	tests := []struct {
+		name     string
+		lo       int
+		hi       int
+		pred     func(int) bool
+		expected int
+	}{
+		{
+			name:     "Case 1",
+			lo:       0,
+			hi:       10,
+			pred:     func(i int) bool { return i >= 7 },
+			expected: 7,
+		},
+		{
+			name:     "Case 2",
+			lo:       0,
+			hi:       10,
+			pred:     func(i int) bool { return false },
+			expected: 10,
+		},
+		{
+			name:     "Case 3",
+			lo:       3,
+			hi:       10,
+			pred:     func(i int) bool { return true },
+			expected: 3,
+		},
+		{
+			name:     "Case 4",
+			lo:       5,
+			hi:       5,
+			pred:     func(i int) bool { return true },
+			expected: 5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := modified_binary_search.FirstTrue(test.lo, test.hi, test.pred); got != test.expected {
+				t.Errorf("FirstTrue(%d, %d) = %d, want %d", test.lo, test.hi, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestBisectFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		lo       float64
+		hi       float64
+		eps      float64
+		pred     func(float64) bool
+		expected float64
+	}{
+		{
+			name:     "Case 1",
+			lo:       0,
+			hi:       2,
+			eps:      1e-9,
+			pred:     func(x float64) bool { return x*x >= 2 },
+			expected: math.Sqrt2,
+		},
+		{
+			name:     "Case 2",
+			lo:       -5,
+			hi:       5,
+			eps:      0,
+			pred:     func(x float64) bool { return x >= 1.25 },
+			expected: 1.25,
+		},
+		{
+			name:     "Case 3",
+			lo:       0,
+			hi:       1,
+			eps:      1e-6,
+			pred:     func(x float64) bool { return false },
+			expected: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := modified_binary_search.BisectFloat(test.lo, test.hi, test.eps, test.pred)
+			if got < test.expected || got-test.expected > test.eps {
+				t.Errorf("BisectFloat(%v, %v, %v) = %v, want %v", test.lo, test.hi, test.eps, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestRotatedBinarySearchWithDuplicates(t *testing.T) {
+	tests := []struct {
+		name   string
+		nums   []int
+		target int
+		found  bool
+	}{
+		{
+			name:   "Case 1",
+			nums:   []int{2, 5, 6, 0, 0, 1, 2},
+			target: 0,
+			found:  true,
+		},
+		{
+			name:   "Case 2",
+			nums:   []int{2, 5, 6, 0, 0, 1, 2},
+			target: 3,
+			found:  false,
+		},
+		{
+			name:   "Case 3",
+			nums:   []int{1, 0, 1, 1, 1},
+			target: 0,
+			found:  true,
+		},
+		{
+			name:   "Case 4",
+			nums:   []int{1, 1, 1, 2, 1, 1},
+			target: 2,
+			found:  true,
+		},
+		{
+			name:   "Case 5",
+			nums:   []int{},
+			target: 1,
+			found:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, search := range map[string]func([]int, int) int{
+				"RotatedBinarySearchWithDuplicatesIterative": modified_binary_search.RotatedBinarySearchWithDuplicatesIterative,
+				"RotatedBinarySearchWithDuplicatesRecursive": modified_binary_search.RotatedBinarySearchWithDuplicatesRecursive,
+			} {
+				got := search(test.nums, test.target)
+				if found := got != -1; found != test.found || (found && test.nums[got] != test.target) {
+					t.Errorf("%s(%v, %d) = %d, want found %v", name, test.nums, test.target, got, test.found)
+				}
+			}
+		})
+	}
+}
+
+func TestFindRotationPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		nums     []int
+		expected int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{4, 5, 6, 7, 0, 1, 2},
+			expected: 4,
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{1, 2, 3},
+			expected: 0,
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1, 1, 2, 1},
+			expected: 3,
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{2, 2, 2, 0, 1, 2},
+			expected: 3,
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{3, 3, 3},
+			expected: 0,
+		},
+		{
+			name:     "Case 6",
+			nums:     []int{},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := modified_binary_search.FindRotationPoint(test.nums); got != test.expected {
+				t.Errorf("FindRotationPoint(%v) = %d, want %d", test.nums, got, test.expected)
+			}
+		})
+	}
+
+	if _, ok := modified_binary_search.FindMinInRotated(nil); ok {
+		t.Errorf("FindMinInRotated(nil) found a minimum, want none")
+	}
+	if got, ok := modified_binary_search.FindMinInRotated([]int{3, 3, 1, 3}); !ok || got != 1 {
+		t.Errorf("FindMinInRotated(%v) = %d, %v, want %d, %v", []int{3, 3, 1, 3}, got, ok, 1, true)
+	}
+}
+
+func TestSearchRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		nums          []int
+		target        int
+		expectedFirst int
+		expectedLast  int
+	}{
+		{
+			name:          "Case 1",
+			nums:          []int{5, 7, 7, 8, 8, 10},
+			target:        8,
+			expectedFirst: 3,
+			expectedLast:  4,
+		},
+		{
+			name:          "Case 2",
+			nums:          []int{5, 7, 7, 8, 8, 10},
+			target:        6,
+			expectedFirst: -1,
+			expectedLast:  -1,
+		},
+		{
+			name:          "Case 3",
+			nums:          []int{8, 10, 5, 7, 7, 8},
+			target:        7,
+			expectedFirst: 3,
+			expectedLast:  4,
+		},
+		{
+			name:          "Case 4",
+			nums:          []int{8, 10, 5, 7, 7, 8},
+			target:        8,
+			expectedFirst: 0,
+			expectedLast:  5,
+		},
+		{
+			name:          "Case 5",
+			nums:          []int{},
+			target:        0,
+			expectedFirst: -1,
+			expectedLast:  -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if first, last := modified_binary_search.SearchRange(test.nums, test.target); first != test.expectedFirst || last != test.expectedLast {
+				t.Errorf("SearchRange(%v, %d) = %d, %d, want %d, %d", test.nums, test.target, first, last, test.expectedFirst, test.expectedLast)
+			}
+		})
+	}
+}
+
+// FuzzRotatedSearch compares the rotated array searches against a linear scan.
+// The bytes are turned into a sorted array of small values, so duplicates are common, which is then rotated.
+func FuzzRotatedSearch(f *testing.F) {
+	rng := rand.New(rand.NewSource(19))
+	for i := 0; i < 500; i++ {
+		data := make([]byte, rng.Intn(16))
+		rng.Read(data)
+		f.Add(data, uint8(rng.Intn(16)), int8(rng.Intn(10)-1))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, rotation uint8, target int8) {
+		sorted := make([]int, len(data))
+		for i, b := range data {
+			sorted[i] = int(b % 8)
+		}
+		slices.Sort(sorted)
+		nums := sorted
+		if len(sorted) > 0 {
+			r := int(rotation) % len(sorted)
+			nums = append(slices.Clone(sorted[r:]), sorted[:r]...)
+		}
+
+		// linear scan for the expected results
+		first, last := -1, -1
+		for i, v := range nums {
+			if v == int(target) {
+				if first == -1 {
+					first = i
+				}
+				last = i
+			}
+		}
+		rotationPoint := 0
+		for i := 1; i < len(nums); i++ {
+			if nums[i-1] > nums[i] {
+				rotationPoint = i
+			}
+		}
+
+		for name, search := range map[string]func([]int, int) int{
+			"RotatedBinarySearchWithDuplicatesIterative": modified_binary_search.RotatedBinarySearchWithDuplicatesIterative,
+			"RotatedBinarySearchWithDuplicatesRecursive": modified_binary_search.RotatedBinarySearchWithDuplicatesRecursive,
+		} {
+			got := search(nums, int(target))
+			if (got == -1) != (first == -1) || (got != -1 && nums[got] != int(target)) {
+				t.Fatalf("%s(%v, %d) = %d, want an index of the target or -1", name, nums, target, got)
+			}
+		}
+		if got := modified_binary_search.FindRotationPoint(nums); got != rotationPoint {
+			t.Fatalf("FindRotationPoint(%v) = %d, want %d", nums, got, rotationPoint)
+		}
+		if got, ok := modified_binary_search.FindMinInRotated(nums); ok != (len(nums) > 0) || (ok && got != sorted[0]) {
+			t.Fatalf("FindMinInRotated(%v) = %d, %v, want %v", nums, got, ok, sorted)
+		}
+		if gotFirst, gotLast := modified_binary_search.SearchRange(nums, int(target)); gotFirst != first || gotLast != last {
+			t.Fatalf("SearchRange(%v, %d) = %d, %d, want %d, %d", nums, target, gotFirst, gotLast, first, last)
+		}
+	})
+}
+
+func TestFindPeakElement(t *testing.T) {
+	tests := []struct {
+		name  string
+		nums  []int
+		peaks []int
+	}{
+		{
+			name:  "Case 1",
+			nums:  []int{1, 2, 3, 1},
+			peaks: []int{2},
+		},
+		{
+			name:  "Case 2",
+			nums:  []int{1, 2, 1, 3, 5, 6, 4},
+			peaks: []int{1, 5},
+		},
+		{
+			name:  "Case 3",
+			nums:  []int{5, 4, 3},
+			peaks: []int{0},
+		},
+		{
+			name:  "Case 4",
+			nums:  []int{1, 2, 3},
+			peaks: []int{2},
+		},
+		{
+			name:  "Case 5",
+			nums:  []int{},
+			peaks: []int{-1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := modified_binary_search.FindPeakElement(test.nums); !slices.Contains(test.peaks, got) {
+				t.Errorf("FindPeakElement(%v) = %d, want one of %v", test.nums, got, test.peaks)
+			}
+		})
+	}
+}
+
+func TestFindPeakIn2DGridIsPeak(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+
+	for round := 0; round < 500; round++ {
+		// distinct values make adjacent elements distinct
+		rows, columns := 1+rng.Intn(6), 1+rng.Intn(6)
+		values := rng.Perm(rows * columns)
+		grid := make([][]int, rows)
+		for r := range grid {
+			grid[r] = values[r*columns : (r+1)*columns]
+		}
+
+		row, column := modified_binary_search.FindPeakIn2DGrid(grid)
+		if row < 0 || column < 0 {
+			t.Fatalf("FindPeakIn2DGrid(%v) = %d, %d, want a peak", grid, row, column)
+		}
+		for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			r, c := row+d[0], column+d[1]
+			if r >= 0 && r < rows && c >= 0 && c < columns && grid[r][c] > grid[row][column] {
+				t.Fatalf("FindPeakIn2DGrid(%v) = %d, %d, which is not a peak", grid, row, column)
+			}
+		}
+	}
+
+	if row, column := modified_binary_search.FindPeakIn2DGrid([][]int{}); row != -1 || column != -1 {
+		t.Errorf("FindPeakIn2DGrid([]) = %d, %d, want %d, %d", row, column, -1, -1)
+	}
+}
+
+func TestSearchSortedMatrix(t *testing.T) {
+	matrix := [][]int{
+		{1, 4, 7, 11, 15},
+		{2, 5, 8, 12, 19},
+		{3, 6, 9, 16, 22},
+		{10, 13, 14, 17, 24},
+		{18, 21, 23, 26, 30},
+	}
+	tests := []struct {
+		name           string
+		matrix         [][]int
+		target         int
+		expectedRow    int
+		expectedColumn int
+	}{
+		{
+			name:           "Case 1",
+			matrix:         matrix,
+			target:         5,
+			expectedRow:    1,
+			expectedColumn: 1,
+		},
+		{
+			name:           "Case 2",
+			matrix:         matrix,
+			target:         20,
+			expectedRow:    -1,
+			expectedColumn: -1,
+		},
+		{
+			name:           "Case 3",
+			matrix:         matrix,
+			target:         18,
+			expectedRow:    4,
+			expectedColumn: 0,
+		},
+		{
+			name:           "Case 4",
+			matrix:         matrix,
+			target:         15,
+			expectedRow:    0,
+			expectedColumn: 4,
+		},
+		{
+			name:           "Case 5",
+			matrix:         [][]int{},
+			target:         1,
+			expectedRow:    -1,
+			expectedColumn: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if row, column := modified_binary_search.SearchSortedMatrix(test.matrix, test.target); row != test.expectedRow || column != test.expectedColumn {
+				t.Errorf("SearchSortedMatrix(%v, %d) = %d, %d, want %d, %d", test.matrix, test.target, row, column, test.expectedRow, test.expectedColumn)
+			}
+			if row, column := modified_binary_search.SearchSortedMatrixStaircase(test.matrix, test.target); row != test.expectedRow || column != test.expectedColumn {
+				t.Errorf("SearchSortedMatrixStaircase(%v, %d) = %d, %d, want %d, %d", test.matrix, test.target, row, column, test.expectedRow, test.expectedColumn)
+			}
+			if row, column := modified_binary_search.SearchSortedMatrixBinary(test.matrix, test.target); row != test.expectedRow || column != test.expectedColumn {
+				t.Errorf("SearchSortedMatrixBinary(%v, %d) = %d, %d, want %d, %d", test.matrix, test.target, row, column, test.expectedRow, test.expectedColumn)
+			}
+		})
+	}
+}
+
+func TestSplitArrayLargestSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{7, 2, 5, 10, 8},
+			k:        2,
+			expected: 18,
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{1, 2, 3, 4, 5},
+			k:        2,
+			expected: 9,
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1, 4, 4},
+			k:        3,
+			expected: 4,
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{2, 3, 1, 2, 4, 3},
+			k:        1,
+			expected: 15,
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{1, 2},
+			k:        3,
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := modified_binary_search.SplitArrayLargestSum(test.nums, test.k); got != test.expected {
+				t.Errorf("SplitArrayLargestSum(%v, %d) = %d, want %d", test.nums, test.k, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestMinimumShipCapacity(t *testing.T) {
+	tests := []struct {
+		name     string
+		weights  []int
+		days     int
+		expected int
+	}{
+		{
+			name:     "Case 1",
+			weights:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			days:     5,
+			expected: 15,
+		},
+		{
+			name:     "Case 2",
+			weights:  []int{3, 2, 2, 4, 1, 4},
+			days:     3,
+			expected: 6,
+		},
+		{
+			name:     "Case 3",
+			weights:  []int{1, 2, 3, 1, 1},
+			days:     4,
+			expected: 3,
+		},
+		{
+			name:     "Case 4",
+			weights:  []int{5, 5},
+			days:     10,
+			expected: 5,
+		},
+		{
+			name:     "Case 5",
+			weights:  []int{5, 5},
+			days:     0,
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := modified_binary_search.MinimumShipCapacity(test.weights, test.days); got != test.expected {
+				t.Errorf("MinimumShipCapacity(%v, %d) = %d, want %d", test.weights, test.days, got, test.expected)
+			}
+		})
+	}
+}
@@ -69,8 +69,8 @@ func LongestRepeatingCharacterReplacement(s string, k int) int {
 	return lengthOfMaxSubstring
 }
 
-func FindRepeatedSequences(dna string, k int) *structs.Set {
-	output := structs.NewSet()
+func FindRepeatedSequences(dna string, k int) *structs.Set[string] {
+	output := structs.NewSet[string]()
 	sequenceFreq := make(map[string]int)
 	start := 0
 
@@ -86,7 +86,7 @@ func FindRepeatedSequences(dna string, k int) *structs.Set {
 	return output
 }
 
-func FindRepeatedSequencesWithRabinKarpAlgorithm(dna string, k int) *structs.Set {
+func FindRepeatedSequencesWithRabinKarpAlgorithm(dna string, k int) *structs.Set[string] {
 	stringLength := len(dna)
 
 	nucleotidesMapping := map[rune]int{'A': 1, 'C': 2, 'G': 3, 'T': 4}
@@ -99,8 +99,8 @@ func FindRepeatedSequencesWithRabinKarpAlgorithm(dna string, k int) *structs.Set
 
 	hashValue := 0
 
-	hashSet := structs.NewSet()
-	output := structs.NewSet()
+	hashSet := structs.NewSet[int]()
+	output := structs.NewSet[string]()
 
 	for i := 0; i <= stringLength-k; i++ {
 		if i == 0 {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/adyanf/coding-patterns-dsa/patterns/sliding_window"
+	"github.com/adyanf/coding-patterns-dsa/structs"
 )
 
 func TestFindLongestSubstring(t *testing.T) {
@@ -105,71 +106,68 @@ func TestFindRepeatedSequences(t *testing.T) {
 		name     string
 		dna      string
 		k        int
-		expected map[interface{}]bool
+		expected []string
 	}{
 		{
 			name: "Case 1",
 			dna:  "AAAAACCCCCAAAAACCCCCC",
 			k:    8,
-			expected: map[interface{}]bool{
-				"AAAAACCC": true,
-				"AAAACCCC": true,
-				"AAACCCCC": true,
+			expected: []string{
+				"AAAAACCC",
+				"AAAACCCC",
+				"AAACCCCC",
 			},
 		},
 		{
 			name: "Case 2",
 			dna:  "GGGGGGGGGGGGGGGGGGGGGGGGG",
 			k:    9,
-			expected: map[interface{}]bool{
-				"GGGGGGGGG": true,
+			expected: []string{
+				"GGGGGGGGG",
 			},
 		},
 		{
 			name: "Case 3",
 			dna:  "TTTTTCCCCCCCTTTTTTCCCCCCCTTTTTTT",
 			k:    10,
-			expected: map[interface{}]bool{
-				"CCCCCCCTTT": true,
-				"CCCCCCTTTT": true,
-				"CCCCCTTTTT": true,
-				"CCCCTTTTTT": true,
-				"TCCCCCCCTT": true,
-				"TTCCCCCCCT": true,
-				"TTTCCCCCCC": true,
-				"TTTTCCCCCC": true,
-				"TTTTTCCCCC": true,
+			expected: []string{
+				"CCCCCCCTTT",
+				"CCCCCCTTTT",
+				"CCCCCTTTTT",
+				"CCCCTTTTTT",
+				"TCCCCCCCTT",
+				"TTCCCCCCCT",
+				"TTTCCCCCCC",
+				"TTTTCCCCCC",
+				"TTTTTCCCCC",
 			},
 		},
 		{
 			name: "Case 4",
 			dna:  "AAAAAACCCCCCCAAAAAAAACCCCCCCTG",
 			k:    10,
-			expected: map[interface{}]bool{
-				"AAAAAACCCC": true,
-				"AAAAACCCCC": true,
-				"AAAACCCCCC": true,
-				"AAACCCCCCC": true,
+			expected: []string{
+				"AAAAAACCCC",
+				"AAAAACCCCC",
+				"AAAACCCCCC",
+				"AAACCCCCCC",
 			},
 		},
 		{
 			name: "Case 5",
 			dna:  "ATATATATATATATAT",
 			k:    6,
-			expected: map[interface{}]bool{
-				"ATATAT": true,
-				"TATATA": true,
+			expected: []string{
+				"ATATAT",
+				"TATATA",
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		got := sliding_window.FindRepeatedSequences(tc.dna, tc.k)
-		for key := range tc.expected {
-			if !got.Exists(key) {
-				t.Errorf("FindRepeatedSequences(%v, %v) = %v, expected %v", tc.dna, tc.k, got, tc.expected)
-				break
-			}
+		if !got.Equal(structs.NewSet(tc.expected...)) {
+			t.Errorf("FindRepeatedSequences(%v, %v) = %v, expected %v", tc.dna, tc.k, got, tc.expected)
 		}
 	}
 }
@@ -179,71 +177,68 @@ func TestFindRepeatedSequencesWithRabinKarpAlgorithm(t *testing.T) {
 		name     string
 		dna      string
 		k        int
-		expected map[interface{}]bool
+		expected []string
 	}{
 		{
 			name: "Case 1",
 			dna:  "AAAAACCCCCAAAAACCCCCC",
 			k:    8,
-			expected: map[interface{}]bool{
-				"AAAAACCC": true,
-				"AAAACCCC": true,
-				"AAACCCCC": true,
+			expected: []string{
+				"AAAAACCC",
+				"AAAACCCC",
+				"AAACCCCC",
 			},
 		},
 		{
 			name: "Case 2",
 			dna:  "GGGGGGGGGGGGGGGGGGGGGGGGG",
 			k:    9,
-			expected: map[interface{}]bool{
-				"GGGGGGGGG": true,
+			expected: []string{
+				"GGGGGGGGG",
 			},
 		},
 		{
 			name: "Case 3",
 			dna:  "TTTTTCCCCCCCTTTTTTCCCCCCCTTTTTTT",
 			k:    10,
-			expected: map[interface{}]bool{
-				"CCCCCCCTTT": true,
-				"CCCCCCTTTT": true,
-				"CCCCCTTTTT": true,
-				"CCCCTTTTTT": true,
-				"TCCCCCCCTT": true,
-				"TTCCCCCCCT": true,
-				"TTTCCCCCCC": true,
-				"TTTTCCCCCC": true,
-				"TTTTTCCCCC": true,
+			expected: []string{
+				"CCCCCCCTTT",
+				"CCCCCCTTTT",
+				"CCCCCTTTTT",
+				"CCCCTTTTTT",
+				"TCCCCCCCTT",
+				"TTCCCCCCCT",
+				"TTTCCCCCCC",
+				"TTTTCCCCCC",
+				"TTTTTCCCCC",
 			},
 		},
 		{
 			name: "Case 4",
 			dna:  "AAAAAACCCCCCCAAAAAAAACCCCCCCTG",
 			k:    10,
-			expected: map[interface{}]bool{
-				"AAAAAACCCC": true,
-				"AAAAACCCCC": true,
-				"AAAACCCCCC": true,
-				"AAACCCCCCC": true,
+			expected: []string{
+				"AAAAAACCCC",
+				"AAAAACCCCC",
+				"AAAACCCCCC",
+				"AAACCCCCCC",
 			},
 		},
 		{
 			name: "Case 5",
 			dna:  "ATATATATATATATAT",
 			k:    6,
-			expected: map[interface{}]bool{
-				"ATATAT": true,
-				"TATATA": true,
+			expected: []string{
+				"ATATAT",
+				"TATATA",
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		got := sliding_window.FindRepeatedSequencesWithRabinKarpAlgorithm(tc.dna, tc.k)
-		for key := range tc.expected {
-			if !got.Exists(key) {
-				t.Errorf("FindRepeatedSequencesWithRabinKarpAlgorithm(%v, %v) = %v, expected %v", tc.dna, tc.k, got, tc.expected)
-				break
-			}
+		if !got.Equal(structs.NewSet(tc.expected...)) {
+			t.Errorf("FindRepeatedSequencesWithRabinKarpAlgorithm(%v, %v) = %v, expected %v", tc.dna, tc.k, got, tc.expected)
 		}
 	}
 }
@@ -0,0 +1,7 @@
+package top_k_elements
+
+// Introselect and MedianOfMediansPivot expose the pivot strategies of SelectK, so the tests can feed it adversarial pivots
+var (
+	Introselect          = introselect
+	MedianOfMediansPivot = medianOfMediansPivot
+)
@@ -0,0 +1,131 @@
+package top_k_elements
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// smallSelectSize is the input size up to which SelectK simply sorts, which is faster than partitioning for a few elements
+const smallSelectSize = 16
+
+// QuickselectKthLargest returns the k-th largest number from unsorted nums with quickselect, the given nums are not modified.
+// Partitions the numbers around a random pivot into the smaller, equal and larger numbers and continues only in the part containing the answer.
+// This solution has time complexity of O(n) on average and O(n^2) in the worst case, and space complexity of O(n).
+func QuickselectKthLargest(nums []int, k int) (int, error) {
+	if err := validateK(nums, k); err != nil {
+		return 0, err
+	}
+	return selectIndex(slices.Clone(nums), len(nums)-k, randomPivot), nil
+}
+
+// MedianOfMediansKthLargest returns the k-th largest number from unsorted nums, the given nums are not modified.
+// Works like quickselect, but the pivot is the median of the medians of groups of five numbers,
+// which always drops at least 30% of the numbers in each round.
+// This solution has time complexity of O(n) in the worst case and space complexity of O(n).
+func MedianOfMediansKthLargest(nums []int, k int) (int, error) {
+	if err := validateK(nums, k); err != nil {
+		return 0, err
+	}
+	return selectIndex(slices.Clone(nums), len(nums)-k, medianOfMediansPivot), nil
+}
+
+// SelectK returns the k-th largest number from unsorted nums choosing the strategy by the input size, the given nums are not modified.
+// Small inputs are sorted. Larger inputs use introselect: quickselect with random pivots, which is the fastest on average,
+// switching to median of medians pivots as soon as a round drops less than a quarter of the numbers, so unlucky pivots can't make it quadratic.
+// This solution has time complexity of O(n) in the worst case and space complexity of O(n).
+func SelectK(nums []int, k int) (int, error) {
+	if err := validateK(nums, k); err != nil {
+		return 0, err
+	}
+
+	sorted := slices.Clone(nums)
+	if len(nums) <= smallSelectSize {
+		slices.Sort(sorted)
+		return sorted[len(nums)-k], nil
+	}
+	return introselect(sorted, len(nums)-k, randomPivot, medianOfMediansPivot), nil
+}
+
+// validateK checks that k is between 1 and the number of numbers, it returns ErrInvalidK
+// also when k is larger than the number of numbers to select from
+func validateK(nums []int, k int) error {
+	if k < 1 || k > len(nums) {
+		return fmt.Errorf("%w: %d is not between 1 and %d", ErrInvalidK, k, len(nums))
+	}
+	return nil
+}
+
+// introselect returns the number which would be at index if nums were sorted in ascending order, nums is reordered in place.
+// It partitions around the pivots of fastPivot while every round drops at least a quarter of the numbers left, which keeps the rounds
+// shrinking geometrically, and uses safePivot for good after the first round which doesn't, so bad fast pivots cost at most one extra round.
+func introselect(nums []int, index int, fastPivot func(nums []int) int, safePivot func(nums []int) int) int {
+	choosePivot := fastPivot
+	previousSize := 0
+	return selectIndex(nums, index, func(nums []int) int {
+		// the previous round kept more than three quarters of the numbers, so the fast pivots are not good enough
+		if previousSize > 0 && len(nums) > previousSize*3/4 {
+			choosePivot = safePivot
+		}
+		previousSize = len(nums)
+		return choosePivot(nums)
+	})
+}
+
+// selectIndex returns the number which would be at index if nums were sorted in ascending order, nums is reordered in place.
+// choosePivot returns the value to partition the numbers around, it must be one of the numbers.
+func selectIndex(nums []int, index int, choosePivot func(nums []int) int) int {
+	for len(nums) > 1 {
+		pivot := choosePivot(nums)
+		less, greater := partition(nums, pivot)
+
+		// continue only in the part containing index, the pivot is the answer if index falls among the numbers equal to it
+		switch {
+		case index < less:
+			nums = nums[:less]
+		case index >= greater:
+			nums = nums[greater:]
+			index -= greater
+		default:
+			return pivot
+		}
+	}
+	return nums[0]
+}
+
+// partition reorders nums into the numbers smaller than the pivot, the numbers equal to it and the numbers larger than it,
+// and returns where the equal and the larger numbers start. Keeping the equal numbers together makes duplicates cheap.
+func partition(nums []int, pivot int) (less int, greater int) {
+	// nums[:less] < pivot, nums[less:i] == pivot, nums[greater:] > pivot and nums[i:greater] is not partitioned yet
+	less, greater = 0, len(nums)
+	for i := 0; i < greater; {
+		switch {
+		case nums[i] < pivot:
+			nums[i], nums[less] = nums[less], nums[i]
+			less++
+			i++
+		case nums[i] > pivot:
+			greater--
+			nums[i], nums[greater] = nums[greater], nums[i]
+		default:
+			i++
+		}
+	}
+	return less, greater
+}
+
+// randomPivot returns a random number of nums
+func randomPivot(nums []int) int {
+	return nums[rand.Intn(len(nums))]
+}
+
+// medianOfMediansPivot returns the median of the medians of groups of five numbers, nums is reordered in place
+func medianOfMediansPivot(nums []int) int {
+	medians := make([]int, 0, (len(nums)+4)/5)
+	for start := 0; start < len(nums); start += 5 {
+		group := nums[start:min(start+5, len(nums))]
+		slices.Sort(group)
+		medians = append(medians, group[len(group)/2])
+	}
+	return selectIndex(medians, len(medians)/2, medianOfMediansPivot)
+}
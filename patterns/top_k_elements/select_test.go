@@ -0,0 +1,170 @@
+package top_k_elements_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/patterns/top_k_elements"
+)
+
+var selectors = map[string]func(nums []int, k int) (int, error){
+	"FindKthLargest":            top_k_elements.FindKthLargest,
+	"QuickselectKthLargest":     top_k_elements.QuickselectKthLargest,
+	"MedianOfMediansKthLargest": top_k_elements.MedianOfMediansKthLargest,
+	"SelectK":                   top_k_elements.SelectK,
+}
+
+func TestSelectKthLargest(t *testing.T) {
+	tests := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{3, 2, 1, 5, 6, 4},
+			k:        2,
+			expected: 5,
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{3, 2, 3, 1, 2, 4, 5, 5, 6},
+			k:        4,
+			expected: 4,
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{7},
+			k:        1,
+			expected: 7,
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+			k:        13,
+			expected: 2,
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{-1, -5, 20, 18, 0, 3, 3, 11, -9, 14, 6, 8, 2, 17, -3, 9, 12, 1, 4, 7},
+			k:        20,
+			expected: -9,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, selector := range selectors {
+				original := slices.Clone(test.nums)
+				got, err := selector(test.nums, test.k)
+				if err != nil || got != test.expected {
+					t.Errorf("%s(%v, %d) = %d, %v, want %d", name, test.nums, test.k, got, err, test.expected)
+				}
+				if !slices.Equal(test.nums, original) {
+					t.Errorf("%s modified its input to %v, want %v", name, test.nums, original)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectKthLargestInvalidK(t *testing.T) {
+	for name, selector := range selectors {
+		for _, test := range []struct {
+			nums []int
+			k    int
+		}{{[]int{1, 2}, 0}, {[]int{1, 2}, 3}, {[]int{}, 1}} {
+			if _, err := selector(test.nums, test.k); !errors.Is(err, top_k_elements.ErrInvalidK) {
+				t.Errorf("%s(%v, %d) error = %v, want %v", name, test.nums, test.k, err, top_k_elements.ErrInvalidK)
+			}
+		}
+	}
+}
+
+func TestSelectKthLargestMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+
+	for round := 0; round < 300; round++ {
+		// small value ranges give many duplicates, large ones give mostly distinct numbers
+		nums := make([]int, 1+rng.Intn(200))
+		valueRange := 1 + rng.Intn(1000)
+		for i := range nums {
+			nums[i] = rng.Intn(valueRange)
+		}
+		sorted := slices.Sorted(slices.Values(nums))
+		k := 1 + rng.Intn(len(nums))
+		expected := sorted[len(nums)-k]
+
+		for name, selector := range selectors {
+			if got, err := selector(nums, k); err != nil || got != expected {
+				t.Fatalf("%s(%v, %d) = %d, %v, want %d", name, nums, k, got, err, expected)
+			}
+		}
+	}
+}
+
+func TestIntroselectSwitchesPivots(t *testing.T) {
+	rng := rand.New(rand.NewSource(37))
+	nums := rng.Perm(10000)
+	index := 1234
+
+	// the largest number as pivot drops a single number per round, which makes plain quickselect quadratic
+	largestCalls, safeCalls := 0, 0
+	largestPivot := func(nums []int) int {
+		largestCalls++
+		return slices.Max(nums)
+	}
+	safePivot := func(nums []int) int {
+		safeCalls++
+		return top_k_elements.MedianOfMediansPivot(nums)
+	}
+
+	// the first bad round switches to median of medians, which drops at least 30% of the numbers per round
+	if got := top_k_elements.Introselect(slices.Clone(nums), index, largestPivot, safePivot); got != index {
+		t.Errorf("Introselect() = %d, want %d", got, index)
+	}
+	if largestCalls != 1 {
+		t.Errorf("Introselect() used the adversarial pivot in %d rounds, want 1", largestCalls)
+	}
+	if safeCalls == 0 || safeCalls > 30 {
+		t.Errorf("Introselect() used the median of medians pivot in %d rounds, want between 1 and 30", safeCalls)
+	}
+
+	// good pivots never switch
+	medianCalls := 0
+	safeCalls = 0
+	medianPivot := func(nums []int) int {
+		medianCalls++
+		return slices.Sorted(slices.Values(nums))[len(nums)/2]
+	}
+	if got := top_k_elements.Introselect(slices.Clone(nums), index, medianPivot, safePivot); got != index {
+		t.Errorf("Introselect() = %d, want %d", got, index)
+	}
+	if medianCalls == 0 || safeCalls != 0 {
+		t.Errorf("Introselect() used the median pivot in %d and the median of medians pivot in %d rounds, want only the median pivot", medianCalls, safeCalls)
+	}
+}
+
+func BenchmarkKthLargest(b *testing.B) {
+	for _, size := range []int{100, 10000, 1000000} {
+		rng := rand.New(rand.NewSource(int64(size)))
+		nums := make([]int, size)
+		for i := range nums {
+			nums[i] = rng.Int()
+		}
+		// the median is the hardest k for the heap, which keeps k numbers
+		k := size / 2
+
+		for _, name := range []string{"FindKthLargest", "QuickselectKthLargest", "MedianOfMediansKthLargest", "SelectK"} {
+			b.Run(fmt.Sprintf("%s/%d", name, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					selectors[name](nums, k)
+				}
+			})
+		}
+	}
+}
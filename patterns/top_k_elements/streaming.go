@@ -0,0 +1,316 @@
+package top_k_elements
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
+)
+
+var (
+	// ErrInvalidK is returned when a top k is asked for fewer than 1 element
+	ErrInvalidK = errors.New("invalid k")
+	// ErrInvalidCapacity is returned when a SpaceSaving has fewer counters than the k elements it reports
+	ErrInvalidCapacity = errors.New("capacity must be at least k")
+	// ErrInvalidErrorRate is returned when the epsilon of a CountMinSketch is not between 0 and 1
+	ErrInvalidErrorRate = errors.New("error rate must be between 0 and 1")
+	// ErrInvalidFailureProbability is returned when the delta of a CountMinSketch is not between 0 and 1
+	ErrInvalidFailureProbability = errors.New("failure probability must be between 0 and 1")
+)
+
+// Item is an element reported by a StreamingTopK together with its estimated count.
+// The true count of the element is between Count - Error and Count. The bound always holds for ExactTopK and SpaceSaving,
+// while for CountMinSketch it only holds with a probability of at least 1 - delta, a true count may be below Count - Error.
+type Item struct {
+	Element int
+	Count   int
+	Error   int
+}
+
+// StreamingTopK finds the k most frequent elements of a stream which is observed one element at a time,
+// without keeping the whole stream in memory.
+type StreamingTopK interface {
+	// Observe adds one occurrence of x to the stream
+	Observe(x int)
+	// TopK returns at most k items with the largest estimated counts, ordered by descending count and then by ascending element
+	TopK() []Item
+	// Len returns the number of observed elements
+	Len() int
+	// ErrorBound returns how much any reported count may overestimate the true count
+	ErrorBound() int
+}
+
+// sortItems orders the items by descending count and then by ascending element
+func sortItems(items []Item) []Item {
+	slices.SortFunc(items, func(a, b Item) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return cmp.Compare(a.Element, b.Element)
+	})
+	return items
+}
+
+// ExactTopK counts every distinct element exactly with a map and selects the top k with a bounded min heap, like TopKFrequent.
+// Its memory grows with the number of distinct elements, ErrorBound is always 0.
+type ExactTopK struct {
+	k      int
+	counts map[int]int
+	total  int
+}
+
+// NewExactTopK will initialize and return a new ExactTopK reporting the k most frequent elements
+func NewExactTopK(k int) (*ExactTopK, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidK, k)
+	}
+	return &ExactTopK{k: k, counts: make(map[int]int)}, nil
+}
+
+// Observe adds one occurrence of x to the stream, this has time complexity of O(1)
+func (e *ExactTopK) Observe(x int) {
+	e.counts[x]++
+	e.total++
+}
+
+// TopK returns the k most frequent elements, this has time complexity of O(m log k) where m is the number of distinct elements
+func (e *ExactTopK) TopK() []Item {
+	frequencyMinHeap := NewFrequencyMinHeap()
+	for element, count := range e.counts {
+		frequencyMinHeap.Push(Frequency{element: element, count: count})
+		// keep only the k largest frequencies
+		if frequencyMinHeap.Len() > e.k {
+			frequencyMinHeap.Pop()
+		}
+	}
+
+	items := make([]Item, 0, frequencyMinHeap.Len())
+	for !frequencyMinHeap.Empty() {
+		popped := frequencyMinHeap.Pop()
+		items = append(items, Item{Element: popped.element, Count: popped.count})
+	}
+	return sortItems(items)
+}
+
+// Len returns the number of observed elements
+func (e *ExactTopK) Len() int {
+	return e.total
+}
+
+// ErrorBound returns 0, the counts are exact
+func (e *ExactTopK) ErrorBound() int {
+	return 0
+}
+
+// SpaceSaving keeps a fixed number of counters for the elements it monitors.
+// When an unmonitored element arrives and every counter is taken, the counter with the smallest count is reassigned to it
+// and the new element inherits that count as its overestimation error.
+// Every element whose true count is greater than Len() / capacity is guaranteed to be monitored,
+// and every count overestimates the true count by at most Len() / capacity.
+type SpaceSaving struct {
+	k        int
+	capacity int
+	// counters is a min heap of the monitored elements by their count, so the smallest counter is found in O(1)
+	counters *structs.IndexedPriorityQueue[int, spaceSavingCounter]
+	handles  map[int]structs.Handle
+	total    int
+}
+
+// spaceSavingCounter is the estimated count of a monitored element and how much it may overestimate the true count
+type spaceSavingCounter struct {
+	count int
+	error int
+}
+
+// NewSpaceSaving will initialize and return a new SpaceSaving reporting the k most frequent elements with the given number of counters.
+// More counters give smaller errors, a capacity of a few times k is usually enough for skewed streams.
+func NewSpaceSaving(k int, capacity int) (*SpaceSaving, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidK, k)
+	}
+	if capacity < k {
+		return nil, fmt.Errorf("%w: capacity %d, k %d", ErrInvalidCapacity, capacity, k)
+	}
+	return &SpaceSaving{
+		k:        k,
+		capacity: capacity,
+		counters: structs.NewIndexedPriorityQueue[int](func(a, b spaceSavingCounter) bool {
+			return a.count < b.count
+		}),
+		handles: make(map[int]structs.Handle, capacity),
+	}, nil
+}
+
+// Observe adds one occurrence of x to the stream, this has time complexity of O(log capacity)
+func (s *SpaceSaving) Observe(x int) {
+	s.total++
+
+	// increment the counter of a monitored element
+	if handle, ok := s.handles[x]; ok {
+		_, counter, _ := s.counters.Get(handle)
+		counter.count++
+		s.counters.Update(handle, counter)
+		return
+	}
+
+	// monitor the new element with a free counter
+	if s.counters.Len() < s.capacity {
+		s.handles[x] = s.counters.Push(x, spaceSavingCounter{count: 1})
+		return
+	}
+
+	// otherwise take over the smallest counter, x may have occurred up to its count times before without being monitored
+	evicted, smallest := s.counters.Pop()
+	delete(s.handles, evicted)
+	s.handles[x] = s.counters.Push(x, spaceSavingCounter{count: smallest.count + 1, error: smallest.count})
+}
+
+// TopK returns the k monitored elements with the largest counts, this has time complexity of O(capacity log capacity)
+func (s *SpaceSaving) TopK() []Item {
+	items := make([]Item, 0, len(s.handles))
+	for element, handle := range s.handles {
+		_, counter, _ := s.counters.Get(handle)
+		items = append(items, Item{Element: element, Count: counter.count, Error: counter.error})
+	}
+	items = sortItems(items)
+	return items[:min(s.k, len(items))]
+}
+
+// Len returns the number of observed elements
+func (s *SpaceSaving) Len() int {
+	return s.total
+}
+
+// ErrorBound returns the smallest counter once every counter is taken, which is at most Len() / capacity.
+// No element was evicted before, and so no count is overestimated, while a counter is still free.
+func (s *SpaceSaving) ErrorBound() int {
+	if s.counters.Len() < s.capacity {
+		return 0
+	}
+	_, smallest, _ := s.counters.Peek()
+	return smallest.count
+}
+
+// CountMinSketch estimates the counts of the stream with a Count-Min Sketch and keeps the k elements with the largest estimates in a min heap.
+// The sketch is a table of depth rows by width counters, every row hashes the element into one of its counters.
+// Counters only overestimate because of colliding elements, so the estimate is the smallest counter of the element over all rows.
+// With a width of e / epsilon and a depth of ln(1 / delta), an estimate overestimates the true count by more than epsilon * Len()
+// with a probability of at most delta. Its memory doesn't depend on the number of distinct elements.
+type CountMinSketch struct {
+	k         int
+	width     int
+	table     [][]int
+	seeds     []uint64
+	total     int
+	errorRate float64
+	// candidates is a min heap of the k elements with the largest estimates by their estimate
+	candidates *structs.IndexedPriorityQueue[int, int]
+	handles    map[int]structs.Handle
+}
+
+// NewCountMinSketch will initialize and return a new CountMinSketch reporting the k most frequent elements,
+// whose estimates overestimate by more than epsilon times the stream length with a probability of at most delta.
+// The seed picks the hash functions, so the same seed always gives the same estimates.
+func NewCountMinSketch(k int, epsilon float64, delta float64, seed int64) (*CountMinSketch, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidK, k)
+	}
+	if epsilon <= 0 || epsilon >= 1 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidErrorRate, epsilon)
+	}
+	if delta <= 0 || delta >= 1 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFailureProbability, delta)
+	}
+
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	table := make([][]int, depth)
+	seeds := make([]uint64, depth)
+	rng := rand.New(rand.NewSource(seed))
+	for i := range table {
+		table[i] = make([]int, width)
+		seeds[i] = rng.Uint64()
+	}
+
+	return &CountMinSketch{
+		k:         k,
+		width:     width,
+		table:     table,
+		seeds:     seeds,
+		errorRate: epsilon,
+		candidates: structs.NewIndexedPriorityQueue[int](func(a, b int) bool {
+			return a < b
+		}),
+		handles: make(map[int]structs.Handle, k+1),
+	}, nil
+}
+
+// Observe adds one occurrence of x to the stream, this has time complexity of O(depth + log k)
+func (c *CountMinSketch) Observe(x int) {
+	c.total++
+	for row := range c.table {
+		c.table[row][c.column(row, x)]++
+	}
+	estimate := c.Estimate(x)
+
+	// update the estimate of a candidate
+	if handle, ok := c.handles[x]; ok {
+		c.candidates.Update(handle, estimate)
+		return
+	}
+
+	// add x as a candidate while there are less than k, or in place of the candidate with the smallest estimate
+	if c.candidates.Len() == c.k {
+		if _, smallest, _ := c.candidates.Peek(); estimate <= smallest {
+			return
+		}
+		evicted, _ := c.candidates.Pop()
+		delete(c.handles, evicted)
+	}
+	c.handles[x] = c.candidates.Push(x, estimate)
+}
+
+// Estimate returns the estimated count of x, which is never smaller than its true count, this has time complexity of O(depth)
+func (c *CountMinSketch) Estimate(x int) int {
+	estimate := math.MaxInt
+	for row := range c.table {
+		estimate = min(estimate, c.table[row][c.column(row, x)])
+	}
+	return estimate
+}
+
+// TopK returns the candidates with the largest estimates, this has time complexity of O(k log k)
+func (c *CountMinSketch) TopK() []Item {
+	errorBound := c.ErrorBound()
+	items := make([]Item, 0, len(c.handles))
+	for element, handle := range c.handles {
+		_, estimate, _ := c.candidates.Get(handle)
+		items = append(items, Item{Element: element, Count: estimate, Error: min(estimate, errorBound)})
+	}
+	return sortItems(items)
+}
+
+// Len returns the number of observed elements
+func (c *CountMinSketch) Len() int {
+	return c.total
+}
+
+// ErrorBound returns epsilon * Len(), which an estimate exceeds with a probability of at most delta
+func (c *CountMinSketch) ErrorBound() int {
+	return int(math.Ceil(c.errorRate * float64(c.total)))
+}
+
+// column hashes x into a column of the row, every row uses its own seed with the splitmix64 finalizer to spread the bits
+func (c *CountMinSketch) column(row int, x int) int {
+	h := uint64(x) ^ c.seeds[row]
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return int(h % uint64(c.width))
+}
@@ -0,0 +1,224 @@
+package top_k_elements_test
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/patterns/top_k_elements"
+)
+
+// zipfStream returns n elements drawn from a Zipfian distribution over [0, imax] with exponent s,
+// the elements are shuffled through a permutation so the most frequent ones are not simply the smallest numbers
+func zipfStream(seed int64, n int, s float64, imax uint64) []int {
+	rng := rand.New(rand.NewSource(seed))
+	zipf := rand.NewZipf(rng, s, 1, imax)
+	permutation := rng.Perm(int(imax) + 1)
+	stream := make([]int, n)
+	for i := range stream {
+		stream[i] = permutation[zipf.Uint64()]
+	}
+	return stream
+}
+
+func elements(items []top_k_elements.Item) []int {
+	result := make([]int, len(items))
+	for i, item := range items {
+		result[i] = item.Element
+	}
+	return result
+}
+
+func TestStreamingTopKOnZipfianStreams(t *testing.T) {
+	const k = 10
+	newExact := func() top_k_elements.StreamingTopK {
+		topK, _ := top_k_elements.NewExactTopK(k)
+		return topK
+	}
+	newSpaceSaving := func() top_k_elements.StreamingTopK {
+		topK, _ := top_k_elements.NewSpaceSaving(k, 20*k)
+		return topK
+	}
+	newCountMinSketch := func() top_k_elements.StreamingTopK {
+		topK, _ := top_k_elements.NewCountMinSketch(k, 0.001, 0.01, 21)
+		return topK
+	}
+
+	tests := []struct {
+		name   string
+		stream []int
+	}{
+		{
+			name:   "Case 1",
+			stream: zipfStream(1, 200000, 1.2, 10000),
+		},
+		{
+			name:   "Case 2",
+			stream: zipfStream(2, 100000, 1.5, 100000),
+		},
+		{
+			name:   "Case 3",
+			stream: zipfStream(3, 300000, 1.1, 1000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := make(map[int]int)
+			for _, x := range tt.stream {
+				counts[x]++
+			}
+			expected := top_k_elements.TopKFrequent(tt.stream, k)
+			slices.Sort(expected)
+
+			for name, newTopK := range map[string]func() top_k_elements.StreamingTopK{
+				"ExactTopK":      newExact,
+				"SpaceSaving":    newSpaceSaving,
+				"CountMinSketch": newCountMinSketch,
+			} {
+				topK := newTopK()
+				for _, x := range tt.stream {
+					topK.Observe(x)
+				}
+				if topK.Len() != len(tt.stream) {
+					t.Errorf("%s.Len() = %d, want %d", name, topK.Len(), len(tt.stream))
+				}
+
+				// the heavy hitters of a skewed stream stand out, so every mode finds the same elements as TopKFrequent
+				items := topK.TopK()
+				got := elements(items)
+				slices.Sort(got)
+				if !slices.Equal(got, expected) {
+					t.Errorf("%s.TopK() = %v, want %v", name, got, expected)
+				}
+
+				// the counts are ordered and the true counts are within the reported errors
+				if !slices.IsSortedFunc(items, func(a, b top_k_elements.Item) int { return cmp.Compare(b.Count, a.Count) }) {
+					t.Errorf("%s.TopK() = %v is not ordered by descending count", name, items)
+				}
+				for _, item := range items {
+					if item.Error > topK.ErrorBound() {
+						t.Errorf("%s.TopK() item %v has an error above ErrorBound() = %d", name, item, topK.ErrorBound())
+					}
+					if trueCount := counts[item.Element]; trueCount > item.Count || trueCount < item.Count-item.Error {
+						t.Errorf("%s.TopK() item %v, want the true count %d within the error", name, item, trueCount)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSpaceSavingErrorBound(t *testing.T) {
+	stream := zipfStream(4, 50000, 1.05, 5000)
+	spaceSaving, err := top_k_elements.NewSpaceSaving(5, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[int]int)
+	for _, x := range stream {
+		spaceSaving.Observe(x)
+		counts[x]++
+	}
+
+	// the error is at most Len() / capacity and every reported count is within its own error
+	if bound := len(stream) / 50; spaceSaving.ErrorBound() > bound {
+		t.Errorf("ErrorBound() = %d, want at most %d", spaceSaving.ErrorBound(), bound)
+	}
+	for _, item := range spaceSaving.TopK() {
+		if trueCount := counts[item.Element]; trueCount > item.Count || trueCount < item.Count-item.Error {
+			t.Errorf("TopK() item %v, want the true count %d within the error", item, trueCount)
+		}
+	}
+}
+
+func TestCountMinSketchEstimate(t *testing.T) {
+	stream := zipfStream(5, 100000, 1.1, 20000)
+	sketch, err := top_k_elements.NewCountMinSketch(3, 0.005, 0.01, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[int]int)
+	for _, x := range stream {
+		sketch.Observe(x)
+		counts[x]++
+	}
+
+	// estimates never underestimate, and with delta = 1% almost all stay within the error bound
+	exceeded := 0
+	for x, count := range counts {
+		estimate := sketch.Estimate(x)
+		if estimate < count {
+			t.Fatalf("Estimate(%d) = %d, want at least the true count %d", x, estimate, count)
+		}
+		if estimate-count > sketch.ErrorBound() {
+			exceeded++
+		}
+	}
+	if exceeded > len(counts)/50 {
+		t.Errorf("%d of %d estimates exceed ErrorBound() = %d", exceeded, len(counts), sketch.ErrorBound())
+	}
+	if got := sketch.Estimate(-1); got > sketch.ErrorBound() {
+		t.Errorf("Estimate of an unseen element = %d, want at most %d", got, sketch.ErrorBound())
+	}
+}
+
+func TestStreamingTopKErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		create   func() error
+		expected error
+	}{
+		{
+			name:     "Case 1",
+			create:   func() error { _, err := top_k_elements.NewExactTopK(0); return err },
+			expected: top_k_elements.ErrInvalidK,
+		},
+		{
+			name:     "Case 2",
+			create:   func() error { _, err := top_k_elements.NewSpaceSaving(5, 4); return err },
+			expected: top_k_elements.ErrInvalidCapacity,
+		},
+		{
+			name:     "Case 3",
+			create:   func() error { _, err := top_k_elements.NewCountMinSketch(5, 0, 0.1, 1); return err },
+			expected: top_k_elements.ErrInvalidErrorRate,
+		},
+		{
+			name:     "Case 4",
+			create:   func() error { _, err := top_k_elements.NewCountMinSketch(5, 0.1, 1, 1); return err },
+			expected: top_k_elements.ErrInvalidFailureProbability,
+		},
+		{
+			name:     "Case 5",
+			create:   func() error { _, err := top_k_elements.NewSpaceSaving(-1, 10); return err },
+			expected: top_k_elements.ErrInvalidK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.create(); !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStreamingTopKSmallStream(t *testing.T) {
+	spaceSaving, _ := top_k_elements.NewSpaceSaving(2, 3)
+	for _, x := range []int{1, 2, 1, 3, 1, 2, 4} {
+		spaceSaving.Observe(x)
+	}
+
+	// 4 took over the counter of 3, inheriting its count of 1 as the error
+	expected := []top_k_elements.Item{{Element: 1, Count: 3}, {Element: 2, Count: 2}}
+	if got := spaceSaving.TopK(); !slices.Equal(got, expected) {
+		t.Errorf("TopK() = %v, want %v", got, expected)
+	}
+	if spaceSaving.ErrorBound() != 2 {
+		t.Errorf("ErrorBound() = %d, want %d", spaceSaving.ErrorBound(), 2)
+	}
+}
@@ -1,7 +1,13 @@
 package top_k_elements
 
 import (
-	"container/heap"
+	"cmp"
+	"fmt"
+	"maps"
+	"math/bits"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/adyanf/coding-patterns-dsa/structs"
 )
@@ -31,188 +37,430 @@ func TopKFrequent(nums []int, k int) []int {
 	}
 
 	// init frequency min heap
-	var frequencyMinHeap FrequencyMinHeap
-	heap.Init(&frequencyMinHeap)
+	frequencyMinHeap := NewFrequencyMinHeap()
 
 	// populate the heap with number frequencies
 	for key, value := range frequencies {
-		heap.Push(&frequencyMinHeap, Frequency{element: key, count: value})
+		frequencyMinHeap.Push(Frequency{element: key, count: value})
 		// if the heap has length more than k, then pop the smallest frequency (top of the heap)
 		if frequencyMinHeap.Len() > k {
-			heap.Pop(&frequencyMinHeap)
+			frequencyMinHeap.Pop()
 		}
 	}
 
 	// populate the result from the frequency min heap
 	result := make([]int, 0, k)
 	for !frequencyMinHeap.Empty() {
-		popped := heap.Pop(&frequencyMinHeap).(Frequency)
+		popped := frequencyMinHeap.Pop()
 		result = append(result, popped.element)
 	}
 	return result
 }
 
-// FindKthLargest returns the k-th largest number from unsorted nums
-func FindKthLargest(nums []int, k int) int {
+// ElementCount is an element together with the number of times it occurs
+type ElementCount struct {
+	Element int
+	Count   int
+}
+
+// TieBreak decides the order of elements with equal counts.
+// The zero value orders them like SmallerValueFirst.
+type TieBreak struct {
+	compare   func(a, b int) int
+	firstSeen bool
+}
+
+// SmallerValueFirst orders elements with equal counts by ascending value
+func SmallerValueFirst() TieBreak {
+	return TieBreak{compare: cmp.Compare[int]}
+}
+
+// FirstSeenFirst orders elements with equal counts by their first occurrence in the input
+func FirstSeenFirst() TieBreak {
+	return TieBreak{firstSeen: true}
+}
+
+// CustomTieBreak orders elements with equal counts with compare, which returns a negative number if a comes before b
+func CustomTieBreak(compare func(a, b int) int) TieBreak {
+	return TieBreak{compare: compare}
+}
+
+// rankedFrequency is the count of an element and the position of its first occurrence among the distinct elements
+type rankedFrequency struct {
+	element   int
+	count     int
+	firstSeen int
+}
+
+// order compares the frequencies by descending count and then by the tie break, it returns a negative number if a comes first
+func (t TieBreak) order(a, b rankedFrequency) int {
+	if a.count != b.count {
+		return cmp.Compare(b.count, a.count)
+	}
+	if t.firstSeen {
+		return cmp.Compare(a.firstSeen, b.firstSeen)
+	}
+	if t.compare == nil {
+		return cmp.Compare(a.element, b.element)
+	}
+	return t.compare(a.element, b.element)
+}
+
+// rankFrequencies counts the elements of nums and returns the distinct elements in the order of their first occurrence,
+// so iterating them doesn't depend on the random map order
+func rankFrequencies(nums []int) []rankedFrequency {
+	positions := make(map[int]int)
+	var frequencies []rankedFrequency
+	for _, num := range nums {
+		position, ok := positions[num]
+		if !ok {
+			position = len(frequencies)
+			positions[num] = position
+			frequencies = append(frequencies, rankedFrequency{element: num, firstSeen: position})
+		}
+		frequencies[position].count++
+	}
+	return frequencies
+}
+
+// TopKFrequentOrdered returns the k most frequent elements ordered by descending count, elements with equal counts are ordered by the tie break.
+// Unlike TopKFrequent the result is the same on every run.
+// This solution has time complexity of O(n + m log k) and space complexity of O(m), where m is the number of distinct elements.
+func TopKFrequentOrdered(nums []int, k int, tieBreak TieBreak) []int {
+	counts := TopKFrequentOrderedWithCounts(nums, k, tieBreak)
+	result := make([]int, len(counts))
+	for i, count := range counts {
+		result[i] = count.Element
+	}
+	return result
+}
+
+// TopKFrequentOrderedWithCounts returns the k most frequent elements with their counts, ordered like TopKFrequentOrdered.
+// Keeps a min heap of at most k frequencies whose top is the one ordered last, so it is popped when a better one arrives.
+// This solution has time complexity of O(n + m log k) and space complexity of O(m), where m is the number of distinct elements.
+func TopKFrequentOrderedWithCounts(nums []int, k int, tieBreak TieBreak) []ElementCount {
+	if k < 1 {
+		return []ElementCount{}
+	}
+
+	// the top of the heap is the frequency which comes last in the order
+	lastMinHeap := structs.NewHeap(func(a, b rankedFrequency) bool {
+		return tieBreak.order(a, b) > 0
+	})
+	for _, frequency := range rankFrequencies(nums) {
+		lastMinHeap.Push(frequency)
+		if lastMinHeap.Len() > k {
+			lastMinHeap.Pop()
+		}
+	}
+
+	// the heap pops from the last to the first, so the result is filled from its end
+	result := make([]ElementCount, lastMinHeap.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		popped := lastMinHeap.Pop()
+		result[i] = ElementCount{Element: popped.element, Count: popped.count}
+	}
+	return result
+}
+
+// TopKFrequentBucketSort returns the k most frequent elements with their counts, ordered like TopKFrequentOrdered.
+// Puts every element into the bucket of its count, then walks the buckets from the largest count down until k elements are taken.
+// The buckets are filled in the order of the first occurrences, so only the taken buckets need sorting for tie breaks other than FirstSeenFirst.
+// This solution has time complexity of O(n) for FirstSeenFirst and O(n + m log m) in the worst case otherwise,
+// and space complexity of O(n), where m is the number of distinct elements.
+func TopKFrequentBucketSort(nums []int, k int, tieBreak TieBreak) []ElementCount {
+	result := []ElementCount{}
+	if k < 1 {
+		return result
+	}
+
+	// an element occurs at most len(nums) times
+	buckets := make([][]rankedFrequency, len(nums)+1)
+	for _, frequency := range rankFrequencies(nums) {
+		buckets[frequency.count] = append(buckets[frequency.count], frequency)
+	}
+
+	for count := len(nums); count > 0 && len(result) < k; count-- {
+		bucket := buckets[count]
+		if !tieBreak.firstSeen {
+			slices.SortFunc(bucket, tieBreak.order)
+		}
+		for _, frequency := range bucket[:min(len(bucket), k-len(result))] {
+			result = append(result, ElementCount{Element: frequency.element, Count: frequency.count})
+		}
+	}
+	return result
+}
+
+// FindKthLargest returns the k-th largest number from unsorted nums, or ErrInvalidK if k is not between 1 and the number of numbers
+func FindKthLargest(nums []int, k int) (int, error) {
+	if err := validateK(nums, k); err != nil {
+		return 0, err
+	}
+
 	// init min heap to store sorted numbers ascending
-	var minHeap structs.MinHeap
-	heap.Init(&minHeap)
+	minHeap := structs.NewMinHeap[int]()
 
 	// populate the heap with k-first elements
 	for i := 0; i < k; i++ {
-		heap.Push(&minHeap, nums[i])
+		minHeap.Push(nums[i])
 	}
 
 	// at this point since the heap already has k element
 	for i := k; i < len(nums); i++ {
 		// every element that got pushed to the heap must have value larger than the top of the heap
-		if nums[i] > minHeap.Top() {
+		if top, _ := minHeap.Peek(); nums[i] > top {
 			// we must popped the top, before push the current element to maintain heap with size of k
-			heap.Pop(&minHeap)
-			heap.Push(&minHeap, nums[i])
+			minHeap.Pop()
+			minHeap.Push(nums[i])
 		}
 	}
 
 	// the k-th largest element will be the top of the heap after the for loop
-	return minHeap.Top()
+	kthLargest, _ := minHeap.Peek()
+	return kthLargest, nil
 }
 
 // ReorganizeString returns a string that has no identical adjacent characters if possible or return empty string
 func ReorganizeString(str string) string {
-	// calculate the frequency of each element
+	// identical characters must be at least 2 apart to not be adjacent
+	return RearrangeStringKDistanceApart(str, 2)
+}
+
+// RearrangeStringKDistanceApart returns a string with the characters of s where identical characters are at least k apart,
+// or an empty string if that is not possible. Characters are runes, invalid UTF-8 bytes are read as utf8.RuneError.
+// Every k <= 1 is satisfied by s itself.
+// Greedily places the character with the largest remaining count, a placed character then waits in a queue of cooldowns
+// until it may be placed again k positions later, and goes back to the max heap from there.
+// This solution has time complexity of O(n log m) and space complexity of O(m), where m is the number of distinct characters.
+func RearrangeStringKDistanceApart(s string, k int) string {
+	if k <= 1 {
+		return s
+	}
+
+	// calculate the frequency of each character
 	frequencies := make(map[rune]int)
-	for _, ch := range str {
+	for _, ch := range s {
 		frequencies[ch] = frequencies[ch] + 1
 	}
 
-	// init frequency max heap
-	var frequencyMaxHeap FrequencyMaxHeap
-	heap.Init(&frequencyMaxHeap)
-
-	// populate the max heap with number frequencies sorted with the largest frequency in the root
+	// populate the max heap with character frequencies sorted with the largest frequency in the root
+	frequencyMaxHeap := NewFrequencyMaxHeap()
 	for key, value := range frequencies {
-		heap.Push(&frequencyMaxHeap, Frequency{element: int(key), count: value})
+		frequencyMaxHeap.Push(Frequency{element: int(key), count: value})
 	}
 
-	// init result and the previous character
-	result := ""
-	previous := Frequency{}
-
-	// keep iterate as long as the max heap is not empty or we still has previous frequency that hasn't been re-push to the max heap
-	for !frequencyMaxHeap.Empty() || (previous.count != 0 && rune(previous.element) != 0) {
-		// if we have previous frequency but the max heap already empty, then it's not possible to reorganize string without identical adjacent characters
-		if (previous.count != 0 && rune(previous.element) != 0) && frequencyMaxHeap.Empty() {
-			return ""
+	// cooldowns is a queue of the placed characters which still have occurrences left, ordered by the position where they may be placed again.
+	// every position places one character, so at most one cooldown ends per position.
+	type cooldown struct {
+		frequency Frequency
+		readyAt   int
+	}
+	var cooldowns []cooldown
+
+	var result strings.Builder
+	result.Grow(len(s))
+	length := utf8.RuneCountInString(s)
+	for position := 0; position < length; position++ {
+		// the character placed k positions ago may be placed again
+		if len(cooldowns) > 0 && cooldowns[0].readyAt == position {
+			frequencyMaxHeap.Push(cooldowns[0].frequency)
+			cooldowns = cooldowns[1:]
 		}
 
-		// pop element from the top of max heap, add the element char to result, and reduce the count
-		poppedElement := heap.Pop(&frequencyMaxHeap).(Frequency)
-		count, char := poppedElement.count, rune(poppedElement.element)
-		result += string(char)
-		count -= 1
-
-		// if previous element has count > 0, then push it back to max heap, and reset previous frequency
-		if previous.count != 0 && rune(previous.element) != 0 {
-			heap.Push(&frequencyMaxHeap, previous)
-			previous = Frequency{}
+		// if every remaining character is still cooling down, identical characters can't be kept k apart
+		if frequencyMaxHeap.Empty() {
+			return ""
 		}
 
-		// if current element has count > 0, then set it as previous frequency
-		if count != 0 {
-			previous.count = count
-			previous.element = int(char)
+		// place the character with the largest remaining count and let it cool down if it has occurrences left
+		popped := frequencyMaxHeap.Pop()
+		result.WriteRune(rune(popped.element))
+		popped.count--
+		if popped.count > 0 {
+			cooldowns = append(cooldowns, cooldown{frequency: popped, readyAt: position + k})
 		}
 	}
 
-	return result
+	return result.String()
 }
 
-// struct Frequency initialization
-type Frequency struct {
-	element int
-	count   int
+// KthLargestStream finds the k-th largest number of a stream of numbers which are added one at a time.
+// It keeps a min heap of the k largest numbers, whose top is the k-th largest.
+type KthLargestStream struct {
+	k       int
+	minHeap *structs.Heap[int]
 }
 
-// struct FrequencyMinHeap initialization
-type FrequencyMinHeap []Frequency
+// NewKthLargestStream will initialize and return a new KthLargestStream for the k-th largest number, seeded with nums
+func NewKthLargestStream(k int, nums []int) (*KthLargestStream, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidK, k)
+	}
 
-// Len returns the length of the heap
-func (h FrequencyMinHeap) Len() int {
-	return len(h)
+	stream := &KthLargestStream{k: k, minHeap: structs.NewMinHeap[int]()}
+	for _, num := range nums {
+		stream.Add(num)
+	}
+	return stream, nil
 }
 
-// Empty returns true if the heap is empty
-func (h FrequencyMinHeap) Empty() bool {
-	return len(h) == 0
+// Add adds val to the stream and returns the k-th largest number so far,
+// or the smallest number so far while less than k numbers were added.
+// This has time complexity of O(log k).
+func (s *KthLargestStream) Add(val int) int {
+	// every number must be larger than the top of the heap once it holds k numbers
+	s.minHeap.Push(val)
+	if s.minHeap.Len() > s.k {
+		s.minHeap.Pop()
+	}
+
+	kthLargest, _ := s.minHeap.Peek()
+	return kthLargest
 }
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h FrequencyMinHeap) Less(i, j int) bool {
-	return h[i].count < h[j].count || (h[i].count == h[j].count && h[i].element < h[j].element)
+// KClosestPoints returns the k points closest to the origin ordered by their distance, points with the same distance keep their input order.
+// Every point is returned if there are at most k points, k must be at least 1 otherwise ErrInvalidK is returned.
+// Keeps a max heap of the k closest points so far, whose top is the farthest of them and gets replaced by any closer point.
+// The squared distances are compared exactly as 128 bit numbers, so any coordinates work, even math.MinInt and math.MaxInt.
+// This solution has time complexity of O(n log k) and space complexity of O(k).
+func KClosestPoints(points [][2]int, k int) ([][2]int, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidK, k)
+	}
+
+	type rankedPoint struct {
+		distance squaredDistance
+		index    int
+	}
+
+	// the farthest point is at the top, among equally far points the one which comes last in the input
+	farthestMaxHeap := structs.NewHeap(func(a, b rankedPoint) bool {
+		if c := a.distance.compare(b.distance); c != 0 {
+			return c > 0
+		}
+		return a.index > b.index
+	})
+	for i, point := range points {
+		// the squared distance orders the points the same way as the distance
+		farthestMaxHeap.Push(rankedPoint{distance: newSquaredDistance(point), index: i})
+		if farthestMaxHeap.Len() > k {
+			farthestMaxHeap.Pop()
+		}
+	}
+
+	// the heap pops from the farthest to the closest, so the result is filled from its end
+	result := make([][2]int, farthestMaxHeap.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = points[farthestMaxHeap.Pop().index]
+	}
+	return result, nil
 }
 
-// Swap swaps the elements with indexes i and j
-func (h FrequencyMinHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+// squaredDistance is the squared distance of a point to the origin as a 128 bit number split into its high and low 64 bits.
+// A squared coordinate is at most 2^126, so the sum of two of them always fits.
+type squaredDistance struct {
+	hi uint64
+	lo uint64
 }
 
-// Top show the fastest end time
-func (h FrequencyMinHeap) Top() interface{} {
-	return h[0]
+// newSquaredDistance returns x^2 + y^2 of the point without overflowing
+func newSquaredDistance(point [2]int) squaredDistance {
+	x, y := absUint64(point[0]), absUint64(point[1])
+	xHi, xLo := bits.Mul64(x, x)
+	yHi, yLo := bits.Mul64(y, y)
+	lo, carry := bits.Add64(xLo, yLo, 0)
+	hi, _ := bits.Add64(xHi, yHi, carry)
+	return squaredDistance{hi: hi, lo: lo}
 }
 
-// Push pushes an element into the FrequencyMinHeap
-func (h *FrequencyMinHeap) Push(x interface{}) {
-	*h = append(*h, x.(Frequency))
+// compare returns -1, 0 or +1 depending on whether d is smaller than, equal to or larger than other
+func (d squaredDistance) compare(other squaredDistance) int {
+	if d.hi != other.hi {
+		return cmp.Compare(d.hi, other.hi)
+	}
+	return cmp.Compare(d.lo, other.lo)
 }
 
-// Pop pops the element at the top of the FrequencyMinHeap
-func (h *FrequencyMinHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// absUint64 returns the absolute value of x, which fits an uint64 even for math.MinInt
+func absUint64(x int) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+	return uint64(x)
 }
 
-// struct FrequencyMinHeap initialization
-type FrequencyMaxHeap []Frequency
+// TopKFrequentWords returns the k most frequent words ordered by descending frequency, words with the same frequency are ordered lexicographically.
+// Every distinct word is represented by its index in the sorted distinct words, so the frequency max heap, which breaks ties by
+// the smaller element, pops words with the same frequency lexicographically.
+// This solution has time complexity of O(n + m log m) and space complexity of O(m), where m is the number of distinct words.
+func TopKFrequentWords(words []string, k int) []string {
+	// calculate the frequency of each word
+	frequencies := make(map[string]int)
+	for _, word := range words {
+		frequencies[word] = frequencies[word] + 1
+	}
 
-// Len returns the length of the heap
-func (h FrequencyMaxHeap) Len() int {
-	return len(h)
-}
+	// populate the frequency max heap with the lexicographic rank of each word
+	distinct := slices.Sorted(maps.Keys(frequencies))
+	frequencyMaxHeap := NewFrequencyMaxHeap()
+	for rank, word := range distinct {
+		frequencyMaxHeap.Push(Frequency{element: rank, count: frequencies[word]})
+	}
 
-// Empty returns true if the heap is empty
-func (h FrequencyMaxHeap) Empty() bool {
-	return len(h) == 0
+	// pop the k most frequent words
+	result := make([]string, 0, min(max(k, 0), len(distinct)))
+	for !frequencyMaxHeap.Empty() && len(result) < k {
+		result = append(result, distinct[frequencyMaxHeap.Pop().element])
+	}
+	return result
 }
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h FrequencyMaxHeap) Less(i, j int) bool {
-	return h[i].count > h[j].count || (h[i].count == h[j].count && h[i].element < h[j].element)
-}
+// SortCharactersByFrequency returns the characters of s sorted by descending frequency, characters with the same frequency are grouped
+// and ordered by ascending code point. Characters are runes, every invalid UTF-8 byte is read as utf8.RuneError and written as U+FFFD,
+// so the result is longer than s when s isn't valid UTF-8 and the invalid bytes are lost.
+// This solution has time complexity of O(n + m log m) and space complexity of O(n), where m is the number of distinct characters.
+func SortCharactersByFrequency(s string) string {
+	// calculate the frequency of each character
+	frequencies := make(map[rune]int)
+	for _, ch := range s {
+		frequencies[ch] = frequencies[ch] + 1
+	}
+
+	// populate the max heap with character frequencies sorted with the largest frequency in the root
+	frequencyMaxHeap := NewFrequencyMaxHeap()
+	for key, value := range frequencies {
+		frequencyMaxHeap.Push(Frequency{element: int(key), count: value})
+	}
 
-// Swap swaps the elements with indexes i and j
-func (h FrequencyMaxHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+	// write every character as many times as it occurs, from the most frequent one
+	var result strings.Builder
+	result.Grow(len(s))
+	for !frequencyMaxHeap.Empty() {
+		popped := frequencyMaxHeap.Pop()
+		for i := 0; i < popped.count; i++ {
+			result.WriteRune(rune(popped.element))
+		}
+	}
+	return result.String()
 }
 
-// Top show the fastest end time
-func (h FrequencyMaxHeap) Top() interface{} {
-	return h[0]
+// struct Frequency initialization
+type Frequency struct {
+	element int
+	count   int
 }
 
-// Push pushes an element into the FrequencyMaxHeap
-func (h *FrequencyMaxHeap) Push(x interface{}) {
-	*h = append(*h, x.(Frequency))
+// NewFrequencyMinHeap returns a heap with the smallest frequency at the top, ties are broken by the smaller element
+func NewFrequencyMinHeap() *structs.Heap[Frequency] {
+	return structs.NewHeap(func(a, b Frequency) bool {
+		return a.count < b.count || (a.count == b.count && a.element < b.element)
+	})
 }
 
-// Pop pops the element at the top of the FrequencyMaxHeap
-func (h *FrequencyMaxHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// NewFrequencyMaxHeap returns a heap with the largest frequency at the top, ties are broken by the smaller element
+func NewFrequencyMaxHeap() *structs.Heap[Frequency] {
+	return structs.NewHeap(func(a, b Frequency) bool {
+		return a.count > b.count || (a.count == b.count && a.element < b.element)
+	})
 }
@@ -1,7 +1,12 @@
 package top_k_elements_test
 
 import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/adyanf/coding-patterns-dsa/patterns/top_k_elements"
 )
@@ -68,10 +73,11 @@ func TestTopKFrequent(t *testing.T) {
 
 func TestFindKthLargest(t *testing.T) {
 	tests := []struct {
-		name     string
-		nums     []int
-		k        int
-		expected int
+		name        string
+		nums        []int
+		k           int
+		expected    int
+		expectedErr error
 	}{
 		{
 			name:     "Case 1",
@@ -103,13 +109,25 @@ func TestFindKthLargest(t *testing.T) {
 			k:        2,
 			expected: 2,
 		},
+		{
+			name:        "Case 6",
+			nums:        []int{3, 1, 2},
+			k:           4,
+			expectedErr: top_k_elements.ErrInvalidK,
+		},
+		{
+			name:        "Case 7",
+			nums:        []int{3, 1, 2},
+			k:           0,
+			expectedErr: top_k_elements.ErrInvalidK,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := top_k_elements.FindKthLargest(test.nums, test.k)
-			if got != test.expected {
-				t.Errorf("FindKthLargest(%v, %d) = %d, want %d", test.nums, test.k, got, test.expected)
+			got, err := top_k_elements.FindKthLargest(test.nums, test.k)
+			if !errors.Is(err, test.expectedErr) || got != test.expected {
+				t.Errorf("FindKthLargest(%v, %d) = %d, %v, want %d, %v", test.nums, test.k, got, err, test.expected, test.expectedErr)
 			}
 		})
 	}
@@ -157,3 +175,460 @@ func TestReorganizeString(t *testing.T) {
 		})
 	}
 }
+
+func TestTopKFrequentOrdered(t *testing.T) {
+	descending := top_k_elements.CustomTieBreak(func(a, b int) int { return b - a })
+	tests := []struct {
+		name     string
+		nums     []int
+		k        int
+		tieBreak top_k_elements.TieBreak
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{4, 3, 3, 1, 1, 4, 2, 4},
+			k:        3,
+			tieBreak: top_k_elements.SmallerValueFirst(),
+			expected: []int{4, 1, 3},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{4, 3, 3, 1, 1, 4, 2, 4},
+			k:        3,
+			tieBreak: top_k_elements.FirstSeenFirst(),
+			expected: []int{4, 3, 1},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{4, 3, 3, 1, 1, 4, 2, 4},
+			k:        3,
+			tieBreak: descending,
+			expected: []int{4, 3, 1},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{5, 6, 7, 8},
+			k:        2,
+			tieBreak: top_k_elements.TieBreak{},
+			expected: []int{5, 6},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{9, 8, 8},
+			k:        5,
+			tieBreak: top_k_elements.FirstSeenFirst(),
+			expected: []int{8, 9},
+		},
+		{
+			name:     "Case 6",
+			nums:     []int{1, 2},
+			k:        0,
+			tieBreak: top_k_elements.SmallerValueFirst(),
+			expected: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the result must not depend on the map iteration order
+			for run := 0; run < 10; run++ {
+				if got := top_k_elements.TopKFrequentOrdered(test.nums, test.k, test.tieBreak); !slices.Equal(got, test.expected) {
+					t.Fatalf("TopKFrequentOrdered(%v, %d) = %v, want %v", test.nums, test.k, got, test.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestTopKFrequentWithCounts(t *testing.T) {
+	nums := []int{2, 7, 7, 2, 5, 7, 9, 9, 5}
+	expected := []top_k_elements.ElementCount{{Element: 7, Count: 3}, {Element: 2, Count: 2}, {Element: 5, Count: 2}}
+
+	if got := top_k_elements.TopKFrequentOrderedWithCounts(nums, 3, top_k_elements.SmallerValueFirst()); !slices.Equal(got, expected) {
+		t.Errorf("TopKFrequentOrderedWithCounts(%v, 3) = %v, want %v", nums, got, expected)
+	}
+	if got := top_k_elements.TopKFrequentBucketSort(nums, 3, top_k_elements.SmallerValueFirst()); !slices.Equal(got, expected) {
+		t.Errorf("TopKFrequentBucketSort(%v, 3) = %v, want %v", nums, got, expected)
+	}
+}
+
+func TestTopKFrequentBucketSortMatchesHeap(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	tieBreaks := map[string]top_k_elements.TieBreak{
+		"SmallerValueFirst": top_k_elements.SmallerValueFirst(),
+		"FirstSeenFirst":    top_k_elements.FirstSeenFirst(),
+		"Descending":        top_k_elements.CustomTieBreak(func(a, b int) int { return b - a }),
+	}
+
+	for round := 0; round < 300; round++ {
+		nums := make([]int, rng.Intn(60))
+		for i := range nums {
+			nums[i] = rng.Intn(15)
+		}
+		k := rng.Intn(20)
+
+		for name, tieBreak := range tieBreaks {
+			heap := top_k_elements.TopKFrequentOrderedWithCounts(nums, k, tieBreak)
+			bucket := top_k_elements.TopKFrequentBucketSort(nums, k, tieBreak)
+			if !slices.Equal(heap, bucket) {
+				t.Fatalf("%s: TopKFrequentBucketSort(%v, %d) = %v, want %v", name, nums, k, bucket, heap)
+			}
+
+			// the elements of TopKFrequent have the same counts, it only differs in the tie breaks and the order
+			counts := make(map[int]int)
+			for _, num := range nums {
+				counts[num]++
+			}
+			var expectedCounts, gotCounts []int
+			for _, element := range top_k_elements.TopKFrequent(nums, k) {
+				expectedCounts = append(expectedCounts, counts[element])
+			}
+			for _, count := range heap {
+				gotCounts = append(gotCounts, count.Count)
+			}
+			slices.Sort(expectedCounts)
+			slices.Reverse(expectedCounts)
+			if !slices.Equal(gotCounts, expectedCounts) {
+				t.Fatalf("%s: TopKFrequentOrderedWithCounts(%v, %d) counts = %v, want %v", name, nums, k, gotCounts, expectedCounts)
+			}
+		}
+	}
+}
+
+func TestRearrangeStringKDistanceApart(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		k        int
+		expected string
+	}{
+		{
+			name:     "Case 1",
+			str:      "aabbcc",
+			k:        3,
+			expected: "abcabc",
+		},
+		{
+			name:     "Case 2",
+			str:      "aaabc",
+			k:        3,
+			expected: "",
+		},
+		{
+			name:     "Case 3",
+			str:      "aaadbbcc",
+			k:        2,
+			expected: "abacabcd",
+		},
+		{
+			name:     "Case 4",
+			str:      "ééàà",
+			k:        2,
+			expected: "àéàé",
+		},
+		{
+			name:     "Case 5",
+			str:      "aa",
+			k:        1,
+			expected: "aa",
+		},
+		{
+			name:     "Case 6",
+			str:      "",
+			k:        4,
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := top_k_elements.RearrangeStringKDistanceApart(test.str, test.k)
+			if got != test.expected {
+				t.Errorf("RearrangeStringKDistanceApart(%q, %d) = %q, want %q", test.str, test.k, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestReorganizeStringWithNul(t *testing.T) {
+	// NUL is an ordinary character, not a marker for a missing previous character
+	if got := top_k_elements.ReorganizeString("\x00\x00a"); got != "\x00a\x00" {
+		t.Errorf("ReorganizeString(%q) = %q, want %q", "\x00\x00a", got, "\x00a\x00")
+	}
+	if got := top_k_elements.ReorganizeString("\x00\x00"); got != "" {
+		t.Errorf("ReorganizeString(%q) = %q, want %q", "\x00\x00", got, "")
+	}
+}
+
+// randomUnicodeString returns a string of runes picked from a small random alphabet, so characters repeat,
+// mixing NUL, ASCII and multi-byte runes
+func randomUnicodeString(rng *rand.Rand) string {
+	pool := []rune{0, 'a', 'b', 'z', 'é', 'ß', 'Ж', '中', '文', '😀', '🚀', utf8.MaxRune}
+	alphabet := make([]rune, 1+rng.Intn(5))
+	for i := range alphabet {
+		alphabet[i] = pool[rng.Intn(len(pool))]
+	}
+	runes := make([]rune, rng.Intn(25))
+	for i := range runes {
+		runes[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(runes)
+}
+
+func TestRearrangeStringKDistanceApartProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(24))
+
+	for round := 0; round < 3000; round++ {
+		str := randomUnicodeString(rng)
+		k := rng.Intn(6)
+		got := top_k_elements.RearrangeStringKDistanceApart(str, k)
+
+		// a rearrangement exists iff the most frequent characters fit: with m occurrences of the most frequent character
+		// and p characters occurring m times, the first m-1 occurrences each need k positions and the last ones need p
+		counts := make(map[rune]int)
+		for _, ch := range str {
+			counts[ch]++
+		}
+		maxCount, maxCharacters := 0, 0
+		for _, count := range counts {
+			if count > maxCount {
+				maxCount, maxCharacters = count, 0
+			}
+			if count == maxCount {
+				maxCharacters++
+			}
+		}
+		n := utf8.RuneCountInString(str)
+		possible := k <= 1 || n == 0 || (maxCount-1)*k+maxCharacters <= n
+
+		if !possible {
+			if got != "" {
+				t.Fatalf("RearrangeStringKDistanceApart(%q, %d) = %q, want %q", str, k, got, "")
+			}
+			continue
+		}
+
+		// the result is a permutation of the runes of str
+		gotRunes, strRunes := []rune(got), []rune(str)
+		slices.Sort(gotRunes)
+		slices.Sort(strRunes)
+		if !slices.Equal(gotRunes, strRunes) {
+			t.Fatalf("RearrangeStringKDistanceApart(%q, %d) = %q is not a permutation", str, k, got)
+		}
+
+		// identical characters are at least k apart
+		last := make(map[rune]int)
+		for position, ch := range []rune(got) {
+			if previous, ok := last[ch]; ok && position-previous < k {
+				t.Fatalf("RearrangeStringKDistanceApart(%q, %d) = %q has %q only %d apart", str, k, got, ch, position-previous)
+			}
+			last[ch] = position
+		}
+	}
+}
+
+func TestKthLargestStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		k        int
+		nums     []int
+		adds     []int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			k:        3,
+			nums:     []int{4, 5, 8, 2},
+			adds:     []int{3, 5, 10, 9, 4},
+			expected: []int{4, 5, 5, 8, 8},
+		},
+		{
+			name:     "Case 2",
+			k:        4,
+			nums:     []int{7, 7, 7, 7, 8, 3},
+			adds:     []int{2, 10, 9, 9},
+			expected: []int{7, 7, 7, 8},
+		},
+		{
+			name:     "Case 3",
+			k:        2,
+			nums:     []int{},
+			adds:     []int{5, 1, 6, 3},
+			expected: []int{5, 1, 5, 5},
+		},
+		{
+			name:     "Case 4",
+			k:        1,
+			nums:     []int{-1},
+			adds:     []int{-2, 0, -5},
+			expected: []int{-1, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := top_k_elements.NewKthLargestStream(test.k, test.nums)
+			if err != nil {
+				t.Fatalf("NewKthLargestStream(%d, %v) error = %v", test.k, test.nums, err)
+			}
+			got := make([]int, len(test.adds))
+			for i, val := range test.adds {
+				got[i] = stream.Add(val)
+			}
+			if !slices.Equal(got, test.expected) {
+				t.Errorf("Add(%v) = %v, want %v", test.adds, got, test.expected)
+			}
+		})
+	}
+
+	if _, err := top_k_elements.NewKthLargestStream(0, nil); !errors.Is(err, top_k_elements.ErrInvalidK) {
+		t.Errorf("NewKthLargestStream(0) error = %v, want %v", err, top_k_elements.ErrInvalidK)
+	}
+}
+
+func TestKClosestPoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		points      [][2]int
+		k           int
+		expected    [][2]int
+		expectedErr error
+	}{
+		{
+			name:     "Case 1",
+			points:   [][2]int{{1, 3}, {-2, 2}},
+			k:        1,
+			expected: [][2]int{{-2, 2}},
+		},
+		{
+			name:     "Case 2",
+			points:   [][2]int{{3, 3}, {5, -1}, {-2, 4}},
+			k:        2,
+			expected: [][2]int{{3, 3}, {-2, 4}},
+		},
+		{
+			name:     "Case 3",
+			points:   [][2]int{{1, 0}, {0, 1}, {-1, 0}, {2, 2}, {0, 0}},
+			k:        3,
+			expected: [][2]int{{0, 0}, {1, 0}, {0, 1}},
+		},
+		{
+			name:     "Case 4",
+			points:   [][2]int{{1, 1}},
+			k:        5,
+			expected: [][2]int{{1, 1}},
+		},
+		{
+			name:        "Case 5",
+			points:      [][2]int{{1, 1}},
+			k:           0,
+			expectedErr: top_k_elements.ErrInvalidK,
+		},
+		{
+			// the squared distances MaxInt^2 + 1 < MaxInt^2 + 9 < (MaxInt + 1)^2 differ far below the precision of a float64
+			name:     "Case 6",
+			points:   [][2]int{{math.MaxInt, math.MaxInt}, {math.MinInt, 0}, {math.MaxInt, 1}, {3, math.MinInt + 1}, {-1, 1}},
+			k:        4,
+			expected: [][2]int{{-1, 1}, {math.MaxInt, 1}, {3, math.MinInt + 1}, {math.MinInt, 0}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := top_k_elements.KClosestPoints(test.points, test.k)
+			if !errors.Is(err, test.expectedErr) || !slices.Equal(got, test.expected) {
+				t.Errorf("KClosestPoints(%v, %d) = %v, %v, want %v, %v", test.points, test.k, got, err, test.expected, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestTopKFrequentWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		words    []string
+		k        int
+		expected []string
+	}{
+		{
+			name:     "Case 1",
+			words:    []string{"i", "love", "leetcode", "i", "love", "coding"},
+			k:        2,
+			expected: []string{"i", "love"},
+		},
+		{
+			name:     "Case 2",
+			words:    []string{"the", "day", "is", "sunny", "the", "the", "the", "sunny", "is", "is"},
+			k:        4,
+			expected: []string{"the", "is", "sunny", "day"},
+		},
+		{
+			name:     "Case 3",
+			words:    []string{"b", "a", "c", "a", "b", "c"},
+			k:        2,
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "Case 4",
+			words:    []string{"go"},
+			k:        3,
+			expected: []string{"go"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := top_k_elements.TopKFrequentWords(test.words, test.k); !slices.Equal(got, test.expected) {
+				t.Errorf("TopKFrequentWords(%v, %d) = %v, want %v", test.words, test.k, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestSortCharactersByFrequency(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		expected string
+	}{
+		{
+			name:     "Case 1",
+			str:      "tree",
+			expected: "eert",
+		},
+		{
+			name:     "Case 2",
+			str:      "cccaaa",
+			expected: "aaaccc",
+		},
+		{
+			name:     "Case 3",
+			str:      "Aabb",
+			expected: "bbAa",
+		},
+		{
+			name:     "Case 4",
+			str:      "日本日",
+			expected: "日日本",
+		},
+		{
+			name:     "Case 5",
+			str:      "",
+			expected: "",
+		},
+		{
+			name:     "Case 6",
+			str:      "\xffa\xfe\xff",
+			expected: "\uFFFD\uFFFD\uFFFDa",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := top_k_elements.SortCharactersByFrequency(test.str); got != test.expected {
+				t.Errorf("SortCharactersByFrequency(%q) = %q, want %q", test.str, got, test.expected)
+			}
+		})
+	}
+}
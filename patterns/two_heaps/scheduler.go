@@ -0,0 +1,148 @@
+package twoheaps
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
+)
+
+var (
+	// ErrNoRooms is returned when meetings are scheduled without any room
+	ErrNoRooms = errors.New("no rooms to schedule meetings in")
+	// ErrInvalidMeeting is returned when a meeting ends before it starts
+	ErrInvalidMeeting = errors.New("meeting ends before it starts")
+	// ErrNoRoomFits is returned when a meeting has more attendees than the capacity of every room
+	ErrNoRoomFits = errors.New("no room fits the meeting")
+)
+
+// Meeting is a meeting to be scheduled, it lasts End - Start time units and needs a room for its attendees
+type Meeting struct {
+	Start     int
+	End       int
+	Attendees int
+}
+
+// Room is a meeting room, identified by its index in the rooms given to ScheduleMeetings
+type Room struct {
+	Capacity int
+}
+
+// Assignment tells in which room a meeting takes place and when it actually happens
+type Assignment struct {
+	Room  int
+	Start int
+	End   int
+	Delay int
+}
+
+// RoomUsage is the utilization total of a room
+type RoomUsage struct {
+	Meetings int
+	BusyTime int
+}
+
+// Schedule is the result of ScheduleMeetings.
+// Assignments are in the same order as the given meetings and Rooms are in the same order as the given rooms.
+type Schedule struct {
+	Assignments []Assignment
+	Rooms       []RoomUsage
+}
+
+// ScheduleMeetings assigns every meeting to a room, processing meetings by their start time.
+// A meeting takes the free room with the lowest index that fits its attendees,
+// if none is free it is delayed until the fitting room which ends the earliest becomes free, keeping its duration.
+// This solution has time complexity of O(m log m + m * r log r) and space complexity of O(m + r),
+// where m is the number of meetings and r is the number of rooms. Without capacity limits it is O(m log m + m log r).
+func ScheduleMeetings(meetings []Meeting, rooms []Room) (*Schedule, error) {
+	if len(rooms) == 0 && len(meetings) > 0 {
+		return nil, ErrNoRooms
+	}
+
+	// validate the meetings before scheduling anything
+	maxCapacity := 0
+	for _, room := range rooms {
+		maxCapacity = max(maxCapacity, room.Capacity)
+	}
+	for i, meeting := range meetings {
+		if meeting.End < meeting.Start {
+			return nil, fmt.Errorf("%w: meeting %d [%d, %d]", ErrInvalidMeeting, i, meeting.Start, meeting.End)
+		}
+		if meeting.Attendees > maxCapacity {
+			return nil, fmt.Errorf("%w: meeting %d has %d attendees", ErrNoRoomFits, i, meeting.Attendees)
+		}
+	}
+
+	// Sort the meeting indexes by their start time, meetings starting together keep their given order
+	order := make([]int, len(meetings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return meetings[order[i]].Start < meetings[order[j]].Start
+	})
+
+	// Create a min heaps for available rooms and used rooms
+	available := structs.NewMinHeap[int]()
+	usedRooms := NewUsageHeap()
+	for i := range rooms {
+		available.Push(i)
+	}
+
+	schedule := &Schedule{
+		Assignments: make([]Assignment, len(meetings)),
+		Rooms:       make([]RoomUsage, len(rooms)),
+	}
+	for _, index := range order {
+		meeting := meetings[index]
+		fits := func(room int) bool {
+			return rooms[room].Capacity >= meeting.Attendees
+		}
+
+		// free up the rooms that have finished their meetings by the start time
+		for top, ok := usedRooms.Peek(); ok && top.endTime <= meeting.Start; top, ok = usedRooms.Peek() {
+			available.Push(usedRooms.Pop().id)
+		}
+
+		// Allocate the meeting into the available room with the lowest number that fits,
+		// if no such room is available, delay the meeting until the earliest fitting room becomes free
+		start := meeting.Start
+		room, ok := popFirst(available, fits)
+		if !ok {
+			usage, _ := popFirst(usedRooms, func(usage Usage) bool {
+				return fits(usage.id)
+			})
+			room, start = usage.id, usage.endTime
+		}
+		end := start + (meeting.End - meeting.Start)
+		usedRooms.Push(Usage{endTime: end, id: room})
+
+		schedule.Assignments[index] = Assignment{Room: room, Start: start, End: end, Delay: start - meeting.Start}
+		schedule.Rooms[room].Meetings++
+		schedule.Rooms[room].BusyTime += end - start
+	}
+
+	return schedule, nil
+}
+
+// popFirst pops the top-most element of the heap that matches, the skipped elements are pushed back
+func popFirst[T any](h *structs.Heap[T], match func(T) bool) (T, bool) {
+	var skipped []T
+	defer func() {
+		for _, x := range skipped {
+			h.Push(x)
+		}
+	}()
+
+	for !h.Empty() {
+		x := h.Pop()
+		if match(x) {
+			return x, true
+		}
+		skipped = append(skipped, x)
+	}
+
+	var zero T
+	return zero, false
+}
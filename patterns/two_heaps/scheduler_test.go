@@ -0,0 +1,124 @@
+package twoheaps_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	twoheaps "github.com/adyanf/coding-patterns-dsa/patterns/two_heaps"
+)
+
+func TestScheduleMeetings(t *testing.T) {
+	tests := []struct {
+		name     string
+		meetings []twoheaps.Meeting
+		rooms    []twoheaps.Room
+		expected *twoheaps.Schedule
+	}{
+		{
+			name:     "Case 1",
+			meetings: []twoheaps.Meeting{{Start: 0, End: 10}, {Start: 1, End: 5}, {Start: 2, End: 7}, {Start: 3, End: 4}},
+			rooms:    make([]twoheaps.Room, 2),
+			expected: &twoheaps.Schedule{
+				Assignments: []twoheaps.Assignment{
+					{Room: 0, Start: 0, End: 10, Delay: 0},
+					{Room: 1, Start: 1, End: 5, Delay: 0},
+					{Room: 1, Start: 5, End: 10, Delay: 3},
+					{Room: 0, Start: 10, End: 11, Delay: 7},
+				},
+				Rooms: []twoheaps.RoomUsage{{Meetings: 2, BusyTime: 11}, {Meetings: 2, BusyTime: 9}},
+			},
+		},
+		{
+			name:     "Case 2",
+			meetings: []twoheaps.Meeting{{Start: 3, End: 6, Attendees: 2}, {Start: 2, End: 4, Attendees: 5}, {Start: 0, End: 5, Attendees: 8}, {Start: 1, End: 3, Attendees: 1}},
+			rooms:    []twoheaps.Room{{Capacity: 2}, {Capacity: 10}},
+			expected: &twoheaps.Schedule{
+				Assignments: []twoheaps.Assignment{
+					{Room: 0, Start: 3, End: 6, Delay: 0},
+					{Room: 1, Start: 5, End: 7, Delay: 3},
+					{Room: 1, Start: 0, End: 5, Delay: 0},
+					{Room: 0, Start: 1, End: 3, Delay: 0},
+				},
+				Rooms: []twoheaps.RoomUsage{{Meetings: 2, BusyTime: 5}, {Meetings: 2, BusyTime: 7}},
+			},
+		},
+		{
+			name:     "Case 3",
+			meetings: []twoheaps.Meeting{{Start: 0, End: 4, Attendees: 6}, {Start: 1, End: 2, Attendees: 6}},
+			rooms:    []twoheaps.Room{{Capacity: 10}, {Capacity: 4}, {Capacity: 4}},
+			expected: &twoheaps.Schedule{
+				Assignments: []twoheaps.Assignment{
+					{Room: 0, Start: 0, End: 4, Delay: 0},
+					{Room: 0, Start: 4, End: 5, Delay: 3},
+				},
+				Rooms: []twoheaps.RoomUsage{{Meetings: 2, BusyTime: 5}, {}, {}},
+			},
+		},
+		{
+			name:     "Case 4",
+			meetings: []twoheaps.Meeting{{Start: 0, End: 1}, {Start: 1, End: 2}, {Start: 2, End: 3}},
+			rooms:    make([]twoheaps.Room, 3),
+			expected: &twoheaps.Schedule{
+				Assignments: []twoheaps.Assignment{
+					{Room: 0, Start: 0, End: 1, Delay: 0},
+					{Room: 0, Start: 1, End: 2, Delay: 0},
+					{Room: 0, Start: 2, End: 3, Delay: 0},
+				},
+				Rooms: []twoheaps.RoomUsage{{Meetings: 3, BusyTime: 3}, {}, {}},
+			},
+		},
+		{
+			name:     "Case 5",
+			meetings: nil,
+			rooms:    nil,
+			expected: &twoheaps.Schedule{Assignments: []twoheaps.Assignment{}, Rooms: []twoheaps.RoomUsage{}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := twoheaps.ScheduleMeetings(test.meetings, test.rooms)
+			if err != nil {
+				t.Fatalf("ScheduleMeetings(%v, %v) returned error %v", test.meetings, test.rooms, err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("ScheduleMeetings(%v, %v) = %+v, want %+v", test.meetings, test.rooms, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestScheduleMeetingsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		meetings []twoheaps.Meeting
+		rooms    []twoheaps.Room
+		expected error
+	}{
+		{
+			name:     "Case 1",
+			meetings: []twoheaps.Meeting{{Start: 0, End: 1}},
+			rooms:    nil,
+			expected: twoheaps.ErrNoRooms,
+		},
+		{
+			name:     "Case 2",
+			meetings: []twoheaps.Meeting{{Start: 5, End: 1}},
+			rooms:    make([]twoheaps.Room, 1),
+			expected: twoheaps.ErrInvalidMeeting,
+		},
+		{
+			name:     "Case 3",
+			meetings: []twoheaps.Meeting{{Start: 0, End: 1, Attendees: 11}},
+			rooms:    []twoheaps.Room{{Capacity: 10}, {Capacity: 4}},
+			expected: twoheaps.ErrNoRoomFits,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := twoheaps.ScheduleMeetings(test.meetings, test.rooms); !errors.Is(err, test.expected) {
+				t.Errorf("ScheduleMeetings(%v, %v) error = %v, want %v", test.meetings, test.rooms, err, test.expected)
+			}
+		})
+	}
+}
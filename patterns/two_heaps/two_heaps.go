@@ -1,8 +1,9 @@
 package twoheaps
 
 import (
-	"container/heap"
-	"sort"
+	"errors"
+	"fmt"
+	"math"
 
 	"github.com/adyanf/coding-patterns-dsa/structs"
 )
@@ -26,117 +27,97 @@ import (
 //   O(logn) insertion/removal and O(1) retrieval.
 // - Custom priority-based selection: The problem involves selecting the next element based on specific priority at each step, such as processing the largest task or earliest event.
 
-// MostBooked returns the most booked room in the given meetings.
-func MostBooked(meetings [][]int, rooms int) int {
-	// Initialize count array with 0s, it represents the number of meetings in each room
-	count := make([]int, rooms)
-
-	// Create a min heaps for available rooms and used rooms
-	available := &structs.MinHeap{}
-	heap.Init(available)
-
-	usedRooms := &UsageHeap{}
-	heap.Init(usedRooms)
+var (
+	// ErrNoQuantile is returned when a QuantileOfStream is created without any quantile
+	ErrNoQuantile = errors.New("no quantile to track")
+	// ErrInvalidQuantile is returned when a quantile is outside [0, 1]
+	ErrInvalidQuantile = errors.New("quantile must be within [0, 1]")
+	// ErrQuantileNotTracked is returned when asking for a quantile the stream was not created with
+	ErrQuantileNotTracked = errors.New("quantile is not tracked")
+	// ErrEmptyStream is returned when asking for a quantile of an empty stream
+	ErrEmptyStream = errors.New("stream is empty")
+)
 
-	// Initialize the available rooms heap
-	for i := 0; i < rooms; i++ {
-		heap.Push(available, i)
+// MostBooked returns the most booked room in the given meetings, or the error of ScheduleMeetings if the meetings cannot be scheduled.
+// It is a thin wrapper over ScheduleMeetings with rooms of equal capacity.
+func MostBooked(meetings [][]int, rooms int) (int, error) {
+	if rooms < 1 {
+		return 0, fmt.Errorf("%w: %d rooms", ErrNoRooms, rooms)
 	}
-
-	// Sort the meetings by their start time
-	sort.Slice(meetings, func(i, j int) bool {
-		return meetings[i][0] < meetings[j][0]
-	})
-
-	for i := 0; i < len(meetings); i++ {
-		startTime := meetings[i][0]
-		endTime := meetings[i][1]
-
-		// free up the rooms that have finished their meetings by the start time
-		for !usedRooms.Empty() && (*usedRooms)[0].endTime <= startTime {
-			room := heap.Pop(usedRooms).(Usage).id
-			heap.Push(available, room)
-		}
-
-		// if no rooms are available, delay the meeting until a room becomes free
-		if available.Empty() {
-			pair := heap.Pop(usedRooms).(Usage)
-			endTime = pair.endTime + (endTime - startTime)
-			heap.Push(available, pair.id)
-		}
-
-		// Allocate the meeting into the available room with the lowest number
-		room := heap.Pop(available).(int)
-		heap.Push(usedRooms, Usage{endTime: endTime, id: room})
-		count[room]++
+	schedule, err := ScheduleMeetings(toMeetings(meetings), make([]Room, rooms))
+	if err != nil {
+		return 0, err
 	}
 
 	// Find room which holds the most meetings
 	maxMettingsRoom := 0
-	for i := range count {
-		if count[i] > count[maxMettingsRoom] {
+	for i := range schedule.Rooms {
+		if schedule.Rooms[i].Meetings > schedule.Rooms[maxMettingsRoom].Meetings {
 			maxMettingsRoom = i
 		}
 	}
-	return maxMettingsRoom
+	return maxMettingsRoom, nil
 }
 
-// MinimumMachines returns the minimum machines needed to execute all tasks without delay.
-func MinimumMachines(tasks [][]int) int {
-	// Sort tasks at hand based on their start time
-	sort.Slice(tasks, func(i int, j int) bool {
-		return tasks[i][0] < tasks[j][0]
-	})
-
-	// Create machine usage heap
-	machines := &UsageHeap{}
-	heap.Init(machines)
-
-	for _, task := range tasks {
-		startTime, endTime := task[0], task[1]
+// MinimumMachines returns the minimum machines needed to execute all tasks without delay, or ErrInvalidMeeting if a task ends before it starts.
+// It is a thin wrapper over ScheduleMeetings with one machine per task, because the lowest numbered free machine
+// is always taken, the number of machines that got any task is the minimum needed.
+func MinimumMachines(tasks [][]int) (int, error) {
+	schedule, err := ScheduleMeetings(toMeetings(tasks), make([]Room, len(tasks)))
+	if err != nil {
+		return 0, err
+	}
 
-		// if machine usage heap is not empty and the top of machine end time is less than or equal new task start time
-		// then pop the machine usage, because the machine will be reused by new task
-		if !machines.Empty() && machines.Top().(Usage).endTime <= startTime {
-			heap.Pop(machines)
+	machines := 0
+	for _, usage := range schedule.Rooms {
+		if usage.Meetings > 0 {
+			machines++
 		}
-
-		// push machine usage with end time of new task
-		heap.Push(machines, Usage{endTime: endTime})
 	}
+	return machines, nil
+}
 
-	return machines.Len()
+// toMeetings converts [start, end] pairs into meetings without attendees
+func toMeetings(intervals [][]int) []Meeting {
+	meetings := make([]Meeting, len(intervals))
+	for i, interval := range intervals {
+		meetings[i] = Meeting{Start: interval[0], End: interval[1]}
+	}
+	return meetings
 }
 
-// MedianOfStream is a data structure to search a median from a stream of numbers
+// MedianOfStream is a data structure to search a median from a stream of numbers.
+// The zero value is ready to use, the heaps are created on first use.
 type MedianOfStream struct {
 	// We are using two heaps to solve this problem
-	maximumList structs.MaxHeap
-	minimumList structs.MinHeap
+	maximumList *structs.Heap[int]
+	minimumList *structs.Heap[int]
 }
 
-// Init will initializes underlying data to handle median of stream of numbers
+// Init will initializes underlying data to handle median of stream of numbers, it also resets the stream
 func (this *MedianOfStream) Init() {
-	// Init each heap by using container/heap library
-	heap.Init(&this.maximumList)
-	heap.Init(&this.minimumList)
+	// Init each heap with its own ordering
+	this.maximumList = structs.NewMaxHeap[int]()
+	this.minimumList = structs.NewMinHeap[int]()
 }
 
 // InsertNum inserts new number to the underlying data store
 func (this *MedianOfStream) InsertNum(num int) float64 {
+	this.initIfNeeded()
+
 	// If maximum list is empty, or if the number is smaller or equal to the top of maximum list
 	// Then push the number to maximum list, otherwise push the number to minimum list
-	if this.maximumList.Empty() || this.maximumList.Top() >= num {
-		heap.Push(&this.maximumList, num)
+	if top, ok := this.maximumList.Peek(); !ok || top >= num {
+		this.maximumList.Push(num)
 	} else {
-		heap.Push(&this.minimumList, num)
+		this.minimumList.Push(num)
 	}
 
 	// Rebalancing the heaps, so that the number of elements in each heap has max diff of 1
 	if this.maximumList.Len() > this.minimumList.Len()+1 {
-		heap.Push(&this.minimumList, heap.Pop(&this.maximumList).(int))
+		this.minimumList.Push(this.maximumList.Pop())
 	} else if this.maximumList.Len() < this.minimumList.Len() {
-		heap.Push(&this.maximumList, heap.Pop(&this.minimumList).(int))
+		this.maximumList.Push(this.minimumList.Pop())
 	}
 
 	return this.FindMedian()
@@ -144,58 +125,282 @@ func (this *MedianOfStream) InsertNum(num int) float64 {
 
 // FindMedian finds the median of stream of numbers
 func (this *MedianOfStream) FindMedian() float64 {
+	this.initIfNeeded()
+
 	// If the number of elements is equal (even), calculate the top of each heap and divide it by 2
+	maximumTop, _ := this.maximumList.Peek()
 	if this.maximumList.Len() == this.minimumList.Len() {
-		return (float64(this.maximumList.Top()) + float64(this.minimumList.Top())) / 2.0
+		minimumTop, _ := this.minimumList.Peek()
+		return (float64(maximumTop) + float64(minimumTop)) / 2.0
 	}
 	// Otherwise return the top of maximum list
-	return float64(this.maximumList.Top())
+	return float64(maximumTop)
 }
 
-// struct Usage initialization
-type Usage struct {
-	id      int
-	endTime int
+// initIfNeeded creates the heaps of a zero value MedianOfStream
+func (this *MedianOfStream) initIfNeeded() {
+	if this.maximumList == nil {
+		this.Init()
+	}
+}
+
+// QuantileOfStream is a data structure to search quantiles from a stream of numbers.
+// It generalizes MedianOfStream, for every tracked quantile q the numbers are split into a maximum list holding
+// the smallest floor(q*(n-1))+1 numbers and a minimum list holding the rest, so the quantile is interpolated
+// between the tops of both lists. Every insert costs O(log n) per tracked quantile.
+type QuantileOfStream struct {
+	trackers []*quantileTracker
+	size     int
 }
 
-// struct UsageHeap initialization
-type UsageHeap []Usage
+// NewQuantileOfStream will initialize and return a new QuantileOfStream tracking the given quantiles,
+// every quantile must be within [0, 1]
+func NewQuantileOfStream(quantiles ...float64) (*QuantileOfStream, error) {
+	if len(quantiles) == 0 {
+		return nil, ErrNoQuantile
+	}
 
-// Len returns the length of the heap
-func (h UsageHeap) Len() int {
-	return len(h)
+	stream := new(QuantileOfStream)
+	for _, q := range quantiles {
+		if math.IsNaN(q) || q < 0 || q > 1 {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuantile, q)
+		}
+		if stream.tracker(q) != nil {
+			continue
+		}
+		stream.trackers = append(stream.trackers, &quantileTracker{
+			q:           q,
+			maximumList: structs.NewMaxHeap[int](),
+			minimumList: structs.NewMinHeap[int](),
+		})
+	}
+	return stream, nil
 }
 
-// Empty returns true if the heap is empty
-func (h UsageHeap) Empty() bool {
-	return len(h) == 0
+// Len returns the number of numbers inserted into the stream
+func (this *QuantileOfStream) Len() int {
+	return this.size
 }
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h UsageHeap) Less(i, j int) bool {
-	return h[i].endTime < h[j].endTime || (h[i].endTime == h[j].endTime && h[i].id < h[j].id)
+// InsertNum inserts new number to the underlying data store of every tracked quantile
+func (this *QuantileOfStream) InsertNum(num int) {
+	this.size++
+	for _, tracker := range this.trackers {
+		tracker.insertNum(num, this.size)
+	}
 }
 
-// Swap swaps the elements with indexes i and j
-func (h UsageHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+// Quantile returns the q-quantile of the stream of numbers, linearly interpolated between the two closest ranks.
+// q must be one of the quantiles the stream was created with.
+func (this *QuantileOfStream) Quantile(q float64) (float64, error) {
+	tracker := this.tracker(q)
+	if tracker == nil {
+		return 0, fmt.Errorf("%w: %v", ErrQuantileNotTracked, q)
+	}
+	if this.size == 0 {
+		return 0, ErrEmptyStream
+	}
+	return tracker.quantile(this.size), nil
 }
 
-// Top show the fastest end time
-func (h UsageHeap) Top() interface{} {
-	return h[0]
+// tracker returns the tracker of quantile q or nil if q is not tracked
+func (this *QuantileOfStream) tracker(q float64) *quantileTracker {
+	for _, tracker := range this.trackers {
+		if tracker.q == q {
+			return tracker
+		}
+	}
+	return nil
 }
 
-// Push pushes an element into the UsageHeap
-func (h *UsageHeap) Push(x interface{}) {
-	*h = append(*h, x.(Usage))
+// quantileTracker keeps a pair of heaps split at the rank of quantile q
+type quantileTracker struct {
+	q           float64
+	maximumList *structs.Heap[int]
+	minimumList *structs.Heap[int]
 }
 
-// Pop pops the element at the top of the UsageHeap
-func (h *UsageHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// position returns the fractional sorted index of the quantile in a stream of size numbers
+func (t *quantileTracker) position(size int) float64 {
+	return t.q * float64(size-1)
+}
+
+// insertNum inserts the number and rebalances the heaps so the maximum list holds floor(position)+1 numbers
+func (t *quantileTracker) insertNum(num int, size int) {
+	if top, ok := t.maximumList.Peek(); !ok || top >= num {
+		t.maximumList.Push(num)
+	} else {
+		t.minimumList.Push(num)
+	}
+
+	// the target only grows by at most one per insert, so at most one number moves between the heaps
+	target := int(math.Floor(t.position(size))) + 1
+	if t.maximumList.Len() > target {
+		t.minimumList.Push(t.maximumList.Pop())
+	} else if t.maximumList.Len() < target {
+		t.maximumList.Push(t.minimumList.Pop())
+	}
+}
+
+// quantile interpolates between the top of the maximum list and the top of the minimum list
+func (t *quantileTracker) quantile(size int) float64 {
+	lower, _ := t.maximumList.Peek()
+	position := t.position(size)
+	fraction := position - math.Floor(position)
+	if fraction == 0 {
+		return float64(lower)
+	}
+	upper, _ := t.minimumList.Peek()
+	// converting before subtracting keeps the distance between numbers far apart from overflowing
+	return float64(lower) + fraction*(float64(upper)-float64(lower))
+}
+
+// SlidingWindowMedian returns the median of every window of size k while the window slides over nums.
+// Uses a removable median of stream so each slide costs O(log k) amortized.
+func SlidingWindowMedian(nums []int, k int) []float64 {
+	if k <= 0 || k > len(nums) {
+		return nil
+	}
+
+	medians := make([]float64, 0, len(nums)-k+1)
+	window := RemovableMedianOfStream{}
+	window.Init()
+
+	for i, num := range nums {
+		window.InsertNum(num)
+
+		// remove the number that just left the window
+		if i >= k {
+			window.Remove(nums[i-k])
+		}
+
+		// record the median once the first window is full
+		if i >= k-1 {
+			medians = append(medians, window.FindMedian())
+		}
+	}
+
+	return medians
+}
+
+// RemovableMedianOfStream is a data structure to search a median from a stream of numbers which also supports removing numbers.
+// Removed numbers are deleted lazily, they stay inside the heaps until they reach the top of one of them.
+type RemovableMedianOfStream struct {
+	maximumList *structs.Heap[int]
+	minimumList *structs.Heap[int]
+	// number of valid (not removed) elements in each heap
+	maximumSize int
+	minimumSize int
+	// counts of the numbers currently in the stream
+	counts map[int]int
+	// counts of the removed numbers still waiting to be popped, kept per heap so that
+	// removing a duplicate never consumes a stale copy that lives in the other heap
+	maximumDelayed map[int]int
+	minimumDelayed map[int]int
+}
+
+// Init will initializes underlying data to handle median of stream of numbers
+func (this *RemovableMedianOfStream) Init() {
+	this.maximumList = structs.NewMaxHeap[int]()
+	this.minimumList = structs.NewMinHeap[int]()
+	this.maximumSize, this.minimumSize = 0, 0
+	this.counts = make(map[int]int)
+	this.maximumDelayed = make(map[int]int)
+	this.minimumDelayed = make(map[int]int)
+}
+
+// Len returns the number of numbers currently in the stream
+func (this *RemovableMedianOfStream) Len() int {
+	return this.maximumSize + this.minimumSize
+}
+
+// InsertNum inserts new number to the underlying data store
+func (this *RemovableMedianOfStream) InsertNum(num int) float64 {
+	// Same placement rule as MedianOfStream, the maximum list keeps the smaller half
+	if top, ok := this.maximumList.Peek(); !ok || top >= num {
+		this.maximumList.Push(num)
+		this.maximumSize++
+	} else {
+		this.minimumList.Push(num)
+		this.minimumSize++
+	}
+	this.counts[num]++
+
+	this.rebalance()
+	return this.FindMedian()
+}
+
+// Remove removes one occurrence of num from the stream, it returns false if num is not in the stream
+func (this *RemovableMedianOfStream) Remove(num int) bool {
+	if this.counts[num] == 0 {
+		return false
+	}
+	this.counts[num]--
+	if this.counts[num] == 0 {
+		delete(this.counts, num)
+	}
+
+	// Mark the number as removed in the heap it belongs to,
+	// every valid number in the maximum list is smaller than or equal to its top
+	if top, _ := this.maximumList.Peek(); num <= top {
+		this.maximumDelayed[num]++
+		this.maximumSize--
+		this.prune(this.maximumList, this.maximumDelayed)
+	} else {
+		this.minimumDelayed[num]++
+		this.minimumSize--
+		this.prune(this.minimumList, this.minimumDelayed)
+	}
+
+	this.rebalance()
+	return true
+}
+
+// FindMedian finds the median of stream of numbers
+func (this *RemovableMedianOfStream) FindMedian() float64 {
+	maximumTop, _ := this.maximumList.Peek()
+	if this.maximumSize == this.minimumSize {
+		minimumTop, _ := this.minimumList.Peek()
+		return (float64(maximumTop) + float64(minimumTop)) / 2.0
+	}
+	return float64(maximumTop)
+}
+
+// rebalance moves the top between the heaps, so that the number of valid elements in each heap has max diff of 1
+func (this *RemovableMedianOfStream) rebalance() {
+	if this.maximumSize > this.minimumSize+1 {
+		this.minimumList.Push(this.maximumList.Pop())
+		this.maximumSize--
+		this.minimumSize++
+		this.prune(this.maximumList, this.maximumDelayed)
+	} else if this.maximumSize < this.minimumSize {
+		this.maximumList.Push(this.minimumList.Pop())
+		this.minimumSize--
+		this.maximumSize++
+		this.prune(this.minimumList, this.minimumDelayed)
+	}
+}
+
+// prune pops the removed numbers sitting at the top of the heap
+func (this *RemovableMedianOfStream) prune(h *structs.Heap[int], delayed map[int]int) {
+	for top, ok := h.Peek(); ok && delayed[top] > 0; top, ok = h.Peek() {
+		delayed[top]--
+		if delayed[top] == 0 {
+			delete(delayed, top)
+		}
+		h.Pop()
+	}
+}
+
+// struct Usage initialization
+type Usage struct {
+	id      int
+	endTime int
+}
+
+// NewUsageHeap returns a heap with the fastest end time at the top, ties are broken by the smaller id
+func NewUsageHeap() *structs.Heap[Usage] {
+	return structs.NewHeap(func(a, b Usage) bool {
+		return a.endTime < b.endTime || (a.endTime == b.endTime && a.id < b.id)
+	})
 }
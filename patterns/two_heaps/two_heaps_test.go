@@ -1,6 +1,11 @@
 package twoheaps_test
 
 import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
 	"testing"
 
 	twoheaps "github.com/adyanf/coding-patterns-dsa/patterns/two_heaps"
@@ -8,10 +13,11 @@ import (
 
 func TestMostBooked(t *testing.T) {
 	tests := []struct {
-		name     string
-		meetings [][]int
-		rooms    int
-		expected int
+		name        string
+		meetings    [][]int
+		rooms       int
+		expected    int
+		expectedErr error
 	}{
 		{
 			name:     "Case 1",
@@ -43,11 +49,24 @@ func TestMostBooked(t *testing.T) {
 			rooms:    4,
 			expected: 1,
 		},
+		{
+			name:        "Case 6",
+			meetings:    [][]int{{0, 4}, {1, 3}},
+			rooms:       0,
+			expectedErr: twoheaps.ErrNoRooms,
+		},
+		{
+			name:        "Case 7",
+			meetings:    [][]int{{0, 4}, {3, 1}},
+			rooms:       2,
+			expectedErr: twoheaps.ErrInvalidMeeting,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			if got := twoheaps.MostBooked(test.meetings, test.rooms); got != test.expected {
-				t.Errorf("MostBooked(%v, %v) = %v, want %v", test.meetings, test.rooms, got, test.expected)
+			got, err := twoheaps.MostBooked(test.meetings, test.rooms)
+			if !errors.Is(err, test.expectedErr) || got != test.expected {
+				t.Errorf("MostBooked(%v, %v) = %v, %v, want %v, %v", test.meetings, test.rooms, got, err, test.expected, test.expectedErr)
 			}
 		})
 	}
@@ -55,9 +74,10 @@ func TestMostBooked(t *testing.T) {
 
 func TestMinimumMachines(t *testing.T) {
 	tests := []struct {
-		name     string
-		tasks    [][]int
-		expected int
+		name        string
+		tasks       [][]int
+		expected    int
+		expectedErr error
 	}{
 		{
 			name:     "Case 1",
@@ -84,11 +104,17 @@ func TestMinimumMachines(t *testing.T) {
 			tasks:    [][]int{{12, 13}, {13, 15}, {17, 20}, {13, 14}, {19, 21}, {18, 20}},
 			expected: 3,
 		},
+		{
+			name:        "Case 6",
+			tasks:       [][]int{{1, 3}, {5, 2}},
+			expectedErr: twoheaps.ErrInvalidMeeting,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			if got := twoheaps.MinimumMachines(test.tasks); got != test.expected {
-				t.Errorf("MinimumMachines(%v) = %v, want %v", test.tasks, got, test.expected)
+			got, err := twoheaps.MinimumMachines(test.tasks)
+			if !errors.Is(err, test.expectedErr) || got != test.expected {
+				t.Errorf("MinimumMachines(%v) = %v, %v, want %v, %v", test.tasks, got, err, test.expected, test.expectedErr)
 			}
 		})
 	}
@@ -141,3 +167,245 @@ func TestFindMedian(t *testing.T) {
 		})
 	}
 }
+
+func TestFindMedianZeroValue(t *testing.T) {
+	var medianOfStream twoheaps.MedianOfStream
+	if got := medianOfStream.FindMedian(); got != 0 {
+		t.Errorf("FindMedian() of an empty stream = %v, want %v", got, 0)
+	}
+
+	// the heaps are created on the first insert, Init is not needed
+	for _, step := range []struct {
+		num      int
+		expected float64
+	}{{3, 3}, {1, 2}, {5, 3}, {4, 3.5}} {
+		if got := medianOfStream.InsertNum(step.num); got != step.expected {
+			t.Errorf("InsertNum(%d) = %v, want %v", step.num, got, step.expected)
+		}
+	}
+}
+
+func TestSlidingWindowMedian(t *testing.T) {
+	tests := []struct {
+		name     string
+		nums     []int
+		k        int
+		expected []float64
+	}{
+		{
+			name:     "Case 1",
+			nums:     []int{1, 3, -1, -3, 5, 3, 6, 7},
+			k:        3,
+			expected: []float64{1, -1, -1, 3, 5, 6},
+		},
+		{
+			name:     "Case 2",
+			nums:     []int{1, 2, 3, 4, 2, 3, 1, 4, 2},
+			k:        3,
+			expected: []float64{2, 3, 3, 3, 2, 3, 2},
+		},
+		{
+			name:     "Case 3",
+			nums:     []int{1, 4, 2, 3},
+			k:        4,
+			expected: []float64{2.5},
+		},
+		{
+			name:     "Case 4",
+			nums:     []int{2, 2, 2, 2, 2},
+			k:        2,
+			expected: []float64{2, 2, 2, 2},
+		},
+		{
+			name:     "Case 5",
+			nums:     []int{5, 5, 8, 1, 4, 7, 1, 3, 8, 4},
+			k:        4,
+			expected: []float64{5, 4.5, 5.5, 2.5, 3.5, 5, 3.5},
+		},
+		{
+			name:     "Case 6",
+			nums:     []int{1, 2},
+			k:        3,
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := twoheaps.SlidingWindowMedian(test.nums, test.k)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("SlidingWindowMedian(%v, %v) = %v, want %v", test.nums, test.k, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestRemovableMedianOfStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for round := 0; round < 200; round++ {
+		stream := twoheaps.RemovableMedianOfStream{}
+		stream.Init()
+		// keep a plain slice of the numbers in the stream to calculate the median by sorting
+		var numbers []int
+
+		for op := 0; op < 100; op++ {
+			// use a small value range so that most removals hit duplicates
+			num := rng.Intn(6)
+			if len(numbers) > 0 && rng.Intn(2) == 0 {
+				num = numbers[rng.Intn(len(numbers))]
+				if !stream.Remove(num) {
+					t.Fatalf("Remove(%d) = false, want true for stream %v", num, numbers)
+				}
+				numbers = slices.Delete(numbers, slices.Index(numbers, num), slices.Index(numbers, num)+1)
+			} else if rng.Intn(4) == 0 && !slices.Contains(numbers, num) {
+				if stream.Remove(num) {
+					t.Fatalf("Remove(%d) = true, want false for stream %v", num, numbers)
+				}
+			} else {
+				stream.InsertNum(num)
+				numbers = append(numbers, num)
+			}
+
+			if stream.Len() != len(numbers) {
+				t.Fatalf("Len() = %d, want %d", stream.Len(), len(numbers))
+			}
+			if len(numbers) == 0 {
+				continue
+			}
+
+			sorted := slices.Clone(numbers)
+			slices.Sort(sorted)
+			expected := float64(sorted[len(sorted)/2])
+			if len(sorted)%2 == 0 {
+				expected = (float64(sorted[len(sorted)/2-1]) + float64(sorted[len(sorted)/2])) / 2.0
+			}
+			if got := stream.FindMedian(); got != expected {
+				t.Fatalf("FindMedian(%v) = %v, want %v", numbers, got, expected)
+			}
+		}
+	}
+}
+
+func TestQuantileOfStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		numbers  []int
+		q        float64
+		expected float64
+	}{
+		{
+			name:     "Case 1",
+			numbers:  []int{22, 35, 36, 27},
+			q:        0.5,
+			expected: 31,
+		},
+		{
+			name:     "Case 2",
+			numbers:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			q:        0.9,
+			expected: 10,
+		},
+		{
+			name:     "Case 3",
+			numbers:  []int{10, 20, 30, 40, 50},
+			q:        0.99,
+			expected: 49.6,
+		},
+		{
+			name:     "Case 4",
+			numbers:  []int{-1, -22, -3, -4, -5},
+			q:        0,
+			expected: -22,
+		},
+		{
+			name:     "Case 5",
+			numbers:  []int{12, 46, 32},
+			q:        1,
+			expected: 46,
+		},
+		{
+			name:     "Case 6",
+			numbers:  []int{7},
+			q:        0.25,
+			expected: 7,
+		},
+		{
+			name:     "Case 7",
+			numbers:  []int{math.MaxInt, math.MinInt},
+			q:        0.25,
+			expected: float64(math.MinInt / 2),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := twoheaps.NewQuantileOfStream(test.q)
+			if err != nil {
+				t.Fatalf("NewQuantileOfStream(%v) returned error %v", test.q, err)
+			}
+			for _, num := range test.numbers {
+				stream.InsertNum(num)
+			}
+
+			got, err := stream.Quantile(test.q)
+			if err != nil || math.Abs(got-test.expected) > 1e-9 {
+				t.Errorf("Quantile(%v) of %v = %v, %v, want %v", test.q, test.numbers, got, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestQuantileOfStreamMatchesSortedStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	quantiles := []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1}
+
+	stream, err := twoheaps.NewQuantileOfStream(quantiles...)
+	if err != nil {
+		t.Fatalf("NewQuantileOfStream(%v) returned error %v", quantiles, err)
+	}
+	median := twoheaps.MedianOfStream{}
+	median.Init()
+
+	var numbers []int
+	for i := 0; i < 1000; i++ {
+		num := rng.Intn(200) - 100
+		numbers = append(numbers, num)
+		stream.InsertNum(num)
+		median.InsertNum(num)
+
+		sorted := slices.Clone(numbers)
+		slices.Sort(sorted)
+		for _, q := range quantiles {
+			position := q * float64(len(sorted)-1)
+			lower := int(math.Floor(position))
+			upper := int(math.Ceil(position))
+			expected := float64(sorted[lower]) + (position-float64(lower))*float64(sorted[upper]-sorted[lower])
+
+			got, err := stream.Quantile(q)
+			if err != nil || math.Abs(got-expected) > 1e-6 {
+				t.Fatalf("Quantile(%v) of %v = %v, %v, want %v", q, sorted, got, err, expected)
+			}
+		}
+
+		if got, _ := stream.Quantile(0.5); got != median.FindMedian() {
+			t.Fatalf("Quantile(0.5) = %v, want FindMedian() = %v", got, median.FindMedian())
+		}
+	}
+}
+
+func TestQuantileOfStreamErrors(t *testing.T) {
+	if _, err := twoheaps.NewQuantileOfStream(); !errors.Is(err, twoheaps.ErrNoQuantile) {
+		t.Errorf("NewQuantileOfStream() error = %v, want %v", err, twoheaps.ErrNoQuantile)
+	}
+	if _, err := twoheaps.NewQuantileOfStream(0.5, 1.5); !errors.Is(err, twoheaps.ErrInvalidQuantile) {
+		t.Errorf("NewQuantileOfStream(0.5, 1.5) error = %v, want %v", err, twoheaps.ErrInvalidQuantile)
+	}
+
+	stream, _ := twoheaps.NewQuantileOfStream(0.9)
+	if _, err := stream.Quantile(0.9); !errors.Is(err, twoheaps.ErrEmptyStream) {
+		t.Errorf("Quantile(0.9) error = %v, want %v", err, twoheaps.ErrEmptyStream)
+	}
+	stream.InsertNum(1)
+	if _, err := stream.Quantile(0.5); !errors.Is(err, twoheaps.ErrQuantileNotTracked) {
+		t.Errorf("Quantile(0.5) error = %v, want %v", err, twoheaps.ErrQuantileNotTracked)
+	}
+}
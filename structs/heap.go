@@ -1,85 +1,101 @@
 package structs
 
-// struct MinHeap initialization
-type MinHeap []int
+import (
+	"cmp"
+	"container/heap"
+)
+
+// Heap is a binary heap of T ordered by a comparator function.
+// The element for which less returns true against every other element is kept at the top of the heap.
+type Heap[T any] struct {
+	items heapItems[T]
+}
 
-// Len returns the length of the heap
-func (h MinHeap) Len() int {
-	return len(h)
+// NewHeap will initialize and return a new Heap ordered by the given less function.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	h := new(Heap[T])
+	h.items.less = less
+	heap.Init(&h.items)
+	return h
 }
 
-// Empty returns true if the heap is empty
-func (h MinHeap) Empty() bool {
-	return len(h) == 0
+// NewMinHeap will initialize and return a new Heap with the smallest element at the top.
+func NewMinHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) bool {
+		return a < b
+	})
 }
 
-// Less returns true if the element with index i should sort before the element with index j
-func (h MinHeap) Less(i, j int) bool {
-	return h[i] < h[j]
+// NewMaxHeap will initialize and return a new Heap with the largest element at the top.
+func NewMaxHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) bool {
+		return a > b
+	})
 }
 
-// Swap swaps the elements with indexes i and j
-func (h MinHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+// Len returns the length of the heap
+func (h *Heap[T]) Len() int {
+	return h.items.Len()
 }
 
-// Top returns the top element of the MinHeap
-func (h MinHeap) Top() int {
-	return h[0]
+// Empty returns true if the heap is empty
+func (h *Heap[T]) Empty() bool {
+	return h.items.Len() == 0
 }
 
-// Push pushes an element into the MinHeap
-func (h *MinHeap) Push(x interface{}) {
-	*h = append(*h, x.(int))
+// Push pushes an element into the heap
+func (h *Heap[T]) Push(x T) {
+	heap.Push(&h.items, x)
 }
 
-// Pop pops the element at the top of the MinHeap
-func (h *MinHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// Pop pops the element at the top of the heap, it panics if the heap is empty
+func (h *Heap[T]) Pop() T {
+	return heap.Pop(&h.items).(T)
 }
 
-// struct MaxHeap initialization
-type MaxHeap []int
+// Peek returns the element at the top of the heap without removing it,
+// the second return value is false if the heap is empty
+func (h *Heap[T]) Peek() (T, bool) {
+	if h.Empty() {
+		var zero T
+		return zero, false
+	}
+	return h.items.data[0], true
+}
 
-// Len returns the length of the heap
-func (h MaxHeap) Len() int {
-	return len(h)
+// heapItems is the heap.Interface implementation backing Heap
+type heapItems[T any] struct {
+	data []T
+	less func(a, b T) bool
 }
 
-// Empty returns true if the heap is empty
-func (h MaxHeap) Empty() bool {
-	return len(h) == 0
+// Len returns the length of the heap
+func (h heapItems[T]) Len() int {
+	return len(h.data)
 }
 
 // Less returns true if the element with index i should sort before the element with index j
-func (h MaxHeap) Less(i, j int) bool {
-	return h[i] > h[j]
+func (h heapItems[T]) Less(i, j int) bool {
+	return h.less(h.data[i], h.data[j])
 }
 
 // Swap swaps the elements with indexes i and j
-func (h MaxHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
-}
-
-// Top returns the top element of the MaxHeap
-func (h MaxHeap) Top() int {
-	return h[0]
+func (h heapItems[T]) Swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
 }
 
-// Push pushes an element into the MaxHeap
-func (h *MaxHeap) Push(x interface{}) {
-	*h = append(*h, x.(int))
+// Push pushes an element into the heap
+func (h *heapItems[T]) Push(x interface{}) {
+	h.data = append(h.data, x.(T))
 }
 
-// Pop pops the element at the top of the MaxHeap
-func (h *MaxHeap) Pop() interface{} {
-	old := *h
+// Pop pops the last element of the underlying slice
+func (h *heapItems[T]) Pop() interface{} {
+	old := h.data
 	n := len(old)
 	x := old[n-1]
-	*h = old[0 : n-1]
+	var zero T
+	old[n-1] = zero
+	h.data = old[0 : n-1]
 	return x
 }
@@ -0,0 +1,72 @@
+package structs_test
+
+import (
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		newHeap  func() *structs.Heap[int]
+		nums     []int
+		expected []int
+	}{
+		{
+			name:     "Case 1",
+			newHeap:  structs.NewMinHeap[int],
+			nums:     []int{5, 2, 9, -3, 7},
+			expected: []int{-3, 2, 5, 7, 9},
+		},
+		{
+			name:     "Case 2",
+			newHeap:  structs.NewMaxHeap[int],
+			nums:     []int{5, 2, 9, -3, 7},
+			expected: []int{9, 7, 5, 2, -3},
+		},
+		{
+			name:     "Case 3",
+			newHeap:  structs.NewMinHeap[int],
+			nums:     []int{1, 1, 1},
+			expected: []int{1, 1, 1},
+		},
+		{
+			name: "Case 4",
+			newHeap: func() *structs.Heap[int] {
+				return structs.NewHeap(func(a, b int) bool {
+					return a%10 < b%10 || (a%10 == b%10 && a < b)
+				})
+			},
+			nums:     []int{19, 21, 33, 11, 40},
+			expected: []int{40, 11, 21, 33, 19},
+		},
+		{
+			name:     "Case 5",
+			newHeap:  structs.NewMaxHeap[int],
+			nums:     []int{},
+			expected: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		h := tc.newHeap()
+		for _, num := range tc.nums {
+			h.Push(num)
+		}
+		assert.Equal(t, len(tc.nums), h.Len())
+
+		got := []int{}
+		for !h.Empty() {
+			top, ok := h.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, top, h.Pop())
+			got = append(got, top)
+		}
+		assert.Equal(t, tc.expected, got)
+
+		_, ok := h.Peek()
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,157 @@
+package structs
+
+import "container/heap"
+
+// Handle identifies an item pushed into an IndexedPriorityQueue.
+// A handle stays valid until its item is popped or removed from the queue.
+type Handle int
+
+// IndexedPriorityQueue is a priority queue where every pushed item gets a stable Handle,
+// so its priority can be changed or the item removed in O(log n) without popping the items above it.
+type IndexedPriorityQueue[T any, P any] struct {
+	entries indexedEntries[T, P]
+	handles map[Handle]*indexedEntry[T, P]
+	next    Handle
+}
+
+// NewIndexedPriorityQueue will initialize and return a new IndexedPriorityQueue,
+// the item with the priority for which less returns true against every other priority is kept at the top.
+func NewIndexedPriorityQueue[T any, P any](less func(a, b P) bool) *IndexedPriorityQueue[T, P] {
+	q := new(IndexedPriorityQueue[T, P])
+	q.entries.less = less
+	q.handles = make(map[Handle]*indexedEntry[T, P])
+	heap.Init(&q.entries)
+	return q
+}
+
+// Len returns the number of items in the queue
+func (q *IndexedPriorityQueue[T, P]) Len() int {
+	return q.entries.Len()
+}
+
+// Empty returns true if the queue is empty
+func (q *IndexedPriorityQueue[T, P]) Empty() bool {
+	return q.entries.Len() == 0
+}
+
+// Push pushes a value with the given priority into the queue and returns its handle
+func (q *IndexedPriorityQueue[T, P]) Push(value T, priority P) Handle {
+	handle := q.next
+	q.next++
+
+	entry := &indexedEntry[T, P]{handle: handle, value: value, priority: priority}
+	q.handles[handle] = entry
+	heap.Push(&q.entries, entry)
+	return handle
+}
+
+// Pop pops the item at the top of the queue, it panics if the queue is empty
+func (q *IndexedPriorityQueue[T, P]) Pop() (T, P) {
+	entry := heap.Pop(&q.entries).(*indexedEntry[T, P])
+	delete(q.handles, entry.handle)
+	return entry.value, entry.priority
+}
+
+// Peek returns the item at the top of the queue without removing it,
+// the last return value is false if the queue is empty
+func (q *IndexedPriorityQueue[T, P]) Peek() (T, P, bool) {
+	if q.Empty() {
+		var value T
+		var priority P
+		return value, priority, false
+	}
+	entry := q.entries.data[0]
+	return entry.value, entry.priority, true
+}
+
+// Contains returns true if the item of the handle is still in the queue
+func (q *IndexedPriorityQueue[T, P]) Contains(handle Handle) bool {
+	_, ok := q.handles[handle]
+	return ok
+}
+
+// Get returns the value and the priority of the item of the handle,
+// the last return value is false if the item is no longer in the queue
+func (q *IndexedPriorityQueue[T, P]) Get(handle Handle) (T, P, bool) {
+	entry, ok := q.handles[handle]
+	if !ok {
+		var value T
+		var priority P
+		return value, priority, false
+	}
+	return entry.value, entry.priority, true
+}
+
+// Update changes the priority of the item of the handle and restores the heap ordering,
+// it returns false if the item is no longer in the queue
+func (q *IndexedPriorityQueue[T, P]) Update(handle Handle, priority P) bool {
+	entry, ok := q.handles[handle]
+	if !ok {
+		return false
+	}
+	entry.priority = priority
+	heap.Fix(&q.entries, entry.index)
+	return true
+}
+
+// Remove removes the item of the handle from the queue and returns its value,
+// the second return value is false if the item is no longer in the queue
+func (q *IndexedPriorityQueue[T, P]) Remove(handle Handle) (T, bool) {
+	entry, ok := q.handles[handle]
+	if !ok {
+		var value T
+		return value, false
+	}
+	heap.Remove(&q.entries, entry.index)
+	delete(q.handles, handle)
+	return entry.value, true
+}
+
+// indexedEntry is an item of the IndexedPriorityQueue together with its position in the heap
+type indexedEntry[T any, P any] struct {
+	handle   Handle
+	value    T
+	priority P
+	index    int
+}
+
+// indexedEntries is the heap.Interface implementation backing IndexedPriorityQueue
+type indexedEntries[T any, P any] struct {
+	data []*indexedEntry[T, P]
+	less func(a, b P) bool
+}
+
+// Len returns the length of the heap
+func (h indexedEntries[T, P]) Len() int {
+	return len(h.data)
+}
+
+// Less returns true if the element with index i should sort before the element with index j
+func (h indexedEntries[T, P]) Less(i, j int) bool {
+	return h.less(h.data[i].priority, h.data[j].priority)
+}
+
+// Swap swaps the elements with indexes i and j and keeps their recorded positions in sync
+func (h indexedEntries[T, P]) Swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.data[i].index = i
+	h.data[j].index = j
+}
+
+// Push pushes an element into the heap
+func (h *indexedEntries[T, P]) Push(x interface{}) {
+	entry := x.(*indexedEntry[T, P])
+	entry.index = len(h.data)
+	h.data = append(h.data, entry)
+}
+
+// Pop pops the last element of the underlying slice
+func (h *indexedEntries[T, P]) Pop() interface{} {
+	old := h.data
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	h.data = old[0 : n-1]
+	return entry
+}
@@ -0,0 +1,130 @@
+package structs_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	q := structs.NewIndexedPriorityQueue[string](func(a, b int) bool {
+		return a < b
+	})
+
+	a := q.Push("a", 5)
+	b := q.Push("b", 3)
+	c := q.Push("c", 8)
+	d := q.Push("d", 1)
+	assert.Equal(t, 4, q.Len())
+
+	value, priority, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "d", value)
+	assert.Equal(t, 1, priority)
+
+	// decrease key moves the item to the top
+	assert.True(t, q.Update(c, 0))
+	value, _, _ = q.Peek()
+	assert.Equal(t, "c", value)
+
+	// increase key moves the item down
+	assert.True(t, q.Update(c, 10))
+	value, _, _ = q.Peek()
+	assert.Equal(t, "d", value)
+
+	removed, ok := q.Remove(d)
+	assert.True(t, ok)
+	assert.Equal(t, "d", removed)
+	assert.False(t, q.Contains(d))
+	assert.False(t, q.Update(d, 2))
+	_, ok = q.Remove(d)
+	assert.False(t, ok)
+
+	value, priority, ok = q.Get(a)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+	assert.Equal(t, 5, priority)
+
+	var got []string
+	for !q.Empty() {
+		value, _ := q.Pop()
+		got = append(got, value)
+	}
+	assert.Equal(t, []string{"b", "a", "c"}, got)
+	assert.False(t, q.Contains(a))
+	assert.False(t, q.Contains(b))
+
+	_, _, ok = q.Peek()
+	assert.False(t, ok)
+}
+
+func TestIndexedPriorityQueueRandomUpdates(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	for round := 0; round < 50; round++ {
+		q := structs.NewIndexedPriorityQueue[int](func(a, b int) bool {
+			return a < b
+		})
+		// expected holds the priority of every handle that should still be in the queue
+		expected := make(map[structs.Handle]int)
+		var handles []structs.Handle
+
+		for op := 0; op < 500; op++ {
+			switch rng.Intn(5) {
+			case 0, 1:
+				priority := rng.Intn(100)
+				handle := q.Push(len(handles), priority)
+				handles = append(handles, handle)
+				expected[handle] = priority
+			case 2:
+				if len(handles) == 0 {
+					continue
+				}
+				handle := handles[rng.Intn(len(handles))]
+				priority := rng.Intn(100)
+				_, present := expected[handle]
+				assert.Equal(t, present, q.Update(handle, priority))
+				if present {
+					expected[handle] = priority
+				}
+			case 3:
+				if len(handles) == 0 {
+					continue
+				}
+				handle := handles[rng.Intn(len(handles))]
+				_, present := expected[handle]
+				_, ok := q.Remove(handle)
+				assert.Equal(t, present, ok)
+				delete(expected, handle)
+			case 4:
+				if q.Empty() {
+					continue
+				}
+				minimum := -1
+				for _, priority := range expected {
+					if minimum == -1 || priority < minimum {
+						minimum = priority
+					}
+				}
+				_, priority := q.Pop()
+				assert.Equal(t, minimum, priority)
+
+				// forget the handle that was popped
+				for handle := range expected {
+					if !q.Contains(handle) {
+						delete(expected, handle)
+					}
+				}
+			}
+
+			assert.Equal(t, len(expected), q.Len())
+			for handle, priority := range expected {
+				_, got, ok := q.Get(handle)
+				assert.True(t, ok)
+				assert.Equal(t, priority, got)
+			}
+		}
+	}
+}
@@ -25,24 +25,33 @@ func (l *LinkedList) CreateLinkedList(lst []int) {
 }
 
 // DisplayLinkedList method will display the elements of linked list.
+// A cyclic linked list is displayed up to the last node of the cycle, see String.
 func (l *LinkedList) DisplayLinkedList() {
-	temp := l.Head
-	fmt.Print("[")
-	for temp != nil {
-		fmt.Print(temp.Data)
-		temp = temp.Next
-		if temp != nil {
-			fmt.Print(", ")
-		}
-	}
-	fmt.Print("]")
+	fmt.Print(l.String())
 }
 
+// String method will return the elements of linked list, e.g. [1, 2, 3].
+// If the linked list has a cycle, the elements are rendered up to the last node of the cycle
+// followed by the node the cycle goes back to, e.g. [1, 2, 3 -> (back to 2)].
 func (l *LinkedList) String() string {
+	// find the last node of the cycle, so we know where to stop
+	cycleStart := findCycleStart(l.Head)
+	var cycleEnd *LinkedListNode
+	if cycleStart != nil {
+		cycleEnd = cycleStart
+		for cycleEnd.Next != cycleStart {
+			cycleEnd = cycleEnd.Next
+		}
+	}
+
 	str := "["
 	temp := l.Head
 	for temp != nil {
 		str += fmt.Sprintf("%d", temp.Data)
+		if temp == cycleEnd {
+			str += fmt.Sprintf(" -> (back to %d)", cycleStart.Data)
+			break
+		}
 		temp = temp.Next
 		if temp != nil {
 			str += ", "
@@ -51,3 +60,21 @@ func (l *LinkedList) String() string {
 	str += "]"
 	return str
 }
+
+// findCycleStart returns the node where the cycle of the linked list starts or nil if there is no cycle
+func findCycleStart(head *LinkedListNode) *LinkedListNode {
+	slow, fast := head, head
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+		if slow == fast {
+			slow = head
+			for slow != fast {
+				slow = slow.Next
+				fast = fast.Next
+			}
+			return slow
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package structs
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// ListNode is a node of a List.
+type ListNode[T any] struct {
+	Value T
+	next  *ListNode[T]
+	prev  *ListNode[T]
+	list  *List[T]
+}
+
+// Next returns the next node of the list or nil.
+func (n *ListNode[T]) Next() *ListNode[T] {
+	if n.list == nil {
+		return nil
+	}
+	return n.next
+}
+
+// Prev returns the previous node of the list or nil.
+// On a singly linked list the previous node is searched from the front in O(n).
+func (n *ListNode[T]) Prev() *ListNode[T] {
+	if n.list == nil {
+		return nil
+	}
+	if n.list.singly {
+		return n.list.predecessor(n)
+	}
+	return n.prev
+}
+
+// List is a generic linked list which keeps track of its front, back and length.
+// It is either singly linked, where only forward links are kept, or doubly linked, where every node also links to
+// its previous node so inserting before, removing and iterating backward take O(1) per node.
+// The zero value is an empty doubly linked list ready to use.
+type List[T any] struct {
+	front  *ListNode[T]
+	back   *ListNode[T]
+	length int
+	singly bool
+}
+
+// NewSinglyLinkedList will initialize and return a new singly linked List containing the given values.
+func NewSinglyLinkedList[T any](values ...T) *List[T] {
+	l := new(List[T])
+	l.singly = true
+	for _, value := range values {
+		l.PushBack(value)
+	}
+	return l
+}
+
+// NewDoublyLinkedList will initialize and return a new doubly linked List containing the given values.
+func NewDoublyLinkedList[T any](values ...T) *List[T] {
+	l := new(List[T])
+	for _, value := range values {
+		l.PushBack(value)
+	}
+	return l
+}
+
+// Singly returns true if the list only keeps forward links.
+func (l *List[T]) Singly() bool {
+	return l.singly
+}
+
+// Len returns the number of nodes in the list in O(1).
+func (l *List[T]) Len() int {
+	return l.length
+}
+
+// Front returns the first node of the list or nil if the list is empty.
+func (l *List[T]) Front() *ListNode[T] {
+	return l.front
+}
+
+// Back returns the last node of the list or nil if the list is empty.
+func (l *List[T]) Back() *ListNode[T] {
+	return l.back
+}
+
+// PushFront inserts a new node with the value at the front of the list and returns it.
+func (l *List[T]) PushFront(value T) *ListNode[T] {
+	node := &ListNode[T]{Value: value, list: l}
+	l.link(nil, node)
+	return node
+}
+
+// PushBack appends a new node with the value at the back of the list and returns it.
+func (l *List[T]) PushBack(value T) *ListNode[T] {
+	node := &ListNode[T]{Value: value, list: l}
+	l.link(l.back, node)
+	return node
+}
+
+// InsertAfter inserts a new node with the value right after mark and returns it.
+// If mark is not a node of the list, the list is not modified and nil is returned.
+func (l *List[T]) InsertAfter(value T, mark *ListNode[T]) *ListNode[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	node := &ListNode[T]{Value: value, list: l}
+	l.link(mark, node)
+	return node
+}
+
+// InsertBefore inserts a new node with the value right before mark and returns it.
+// If mark is not a node of the list, the list is not modified and nil is returned.
+// On a singly linked list it takes O(n) to find the node before mark.
+func (l *List[T]) InsertBefore(value T, mark *ListNode[T]) *ListNode[T] {
+	if mark == nil || mark.list != l {
+		return nil
+	}
+	node := &ListNode[T]{Value: value, list: l}
+	l.link(mark.Prev(), node)
+	return node
+}
+
+// Remove removes the node from the list and returns its value.
+// If the node is not a node of the list, the list is not modified.
+// On a singly linked list it takes O(n) to find the node before the removed one.
+func (l *List[T]) Remove(node *ListNode[T]) T {
+	if node == nil {
+		var zero T
+		return zero
+	}
+	if node.list != l {
+		return node.Value
+	}
+
+	prev := node.Prev()
+	if prev == nil {
+		l.front = node.next
+	} else {
+		prev.next = node.next
+	}
+	if node.next == nil {
+		l.back = prev
+	} else if !l.singly {
+		node.next.prev = prev
+	}
+
+	// detach the node so it can't be used to walk into the list anymore
+	node.next, node.prev, node.list = nil, nil, nil
+	l.length--
+	return node.Value
+}
+
+// Find returns the first node whose value matches or nil if there is none.
+func (l *List[T]) Find(match func(T) bool) *ListNode[T] {
+	for node := l.front; node != nil; node = node.next {
+		if match(node.Value) {
+			return node
+		}
+	}
+	return nil
+}
+
+// All returns an iterator over the values of the list from front to back.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l.front; node != nil; node = node.next {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the list from back to front.
+// On a singly linked list the values are collected first, which takes O(n) extra space.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.singly {
+			values := l.Slice()
+			for i := len(values) - 1; i >= 0; i-- {
+				if !yield(values[i]) {
+					return
+				}
+			}
+			return
+		}
+
+		for node := l.back; node != nil; node = node.prev {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the values of the list from front to back.
+func (l *List[T]) Slice() []T {
+	values := make([]T, 0, l.length)
+	for value := range l.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// String returns the values of the list from front to back, e.g. [1, 2, 3].
+func (l *List[T]) String() string {
+	values := make([]string, 0, l.length)
+	for value := range l.All() {
+		values = append(values, fmt.Sprint(value))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// link inserts node right after prev, or at the front if prev is nil
+func (l *List[T]) link(prev *ListNode[T], node *ListNode[T]) {
+	if prev == nil {
+		node.next = l.front
+		l.front = node
+	} else {
+		node.next = prev.next
+		prev.next = node
+	}
+
+	if !l.singly {
+		node.prev = prev
+		if node.next != nil {
+			node.next.prev = node
+		}
+	}
+	if node.next == nil {
+		l.back = node
+	}
+	l.length++
+}
+
+// predecessor returns the node right before node by walking from the front
+func (l *List[T]) predecessor(node *ListNode[T]) *ListNode[T] {
+	var prev *ListNode[T]
+	for curr := l.front; curr != nil && curr != node; curr = curr.next {
+		prev = curr
+	}
+	return prev
+}
@@ -0,0 +1,101 @@
+package structs_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	testCases := []struct {
+		name    string
+		newList func(values ...int) *structs.List[int]
+	}{
+		{
+			name:    "Singly",
+			newList: structs.NewSinglyLinkedList[int],
+		},
+		{
+			name:    "Doubly",
+			newList: structs.NewDoublyLinkedList[int],
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := tc.newList(2, 4)
+			assert.Equal(t, []int{2, 4}, l.Slice())
+
+			one := l.PushFront(1)
+			five := l.PushBack(5)
+			three := l.InsertAfter(3, l.Find(func(v int) bool { return v == 2 }))
+			zero := l.InsertBefore(0, one)
+			l.InsertBefore(45, five)
+			assert.Equal(t, []int{0, 1, 2, 3, 4, 45, 5}, l.Slice())
+			assert.Equal(t, 7, l.Len())
+			assert.Equal(t, "[0, 1, 2, 3, 4, 45, 5]", l.String())
+			assert.Equal(t, []int{5, 45, 4, 3, 2, 1, 0}, slices.Collect(l.Backward()))
+
+			assert.Equal(t, one, zero.Next())
+			assert.Equal(t, zero, one.Prev())
+			assert.Nil(t, zero.Prev())
+			assert.Equal(t, 3, three.Value)
+
+			// remove from the front, the middle and the back
+			assert.Equal(t, 0, l.Remove(zero))
+			assert.Equal(t, 3, l.Remove(three))
+			assert.Equal(t, 5, l.Remove(five))
+			assert.Equal(t, []int{1, 2, 4, 45}, l.Slice())
+			assert.Equal(t, []int{45, 4, 2, 1}, slices.Collect(l.Backward()))
+			assert.Equal(t, 1, l.Front().Value)
+			assert.Equal(t, 45, l.Back().Value)
+			assert.Equal(t, 4, l.Len())
+
+			// removed nodes are detached and can't be used as marks anymore
+			assert.Nil(t, five.Next())
+			assert.Nil(t, l.InsertAfter(6, five))
+			assert.Equal(t, 5, l.Remove(five))
+			assert.Equal(t, 4, l.Len())
+
+			// nodes of another list are rejected
+			other := tc.newList(9)
+			assert.Nil(t, l.InsertBefore(7, other.Front()))
+			assert.Equal(t, 9, l.Remove(other.Front()))
+			assert.Equal(t, 1, other.Len())
+
+			assert.Nil(t, l.Find(func(v int) bool { return v > 100 }))
+
+			// stop iterating early
+			var firstTwo []int
+			for v := range l.All() {
+				if len(firstTwo) == 2 {
+					break
+				}
+				firstTwo = append(firstTwo, v)
+			}
+			assert.Equal(t, []int{1, 2}, firstTwo)
+
+			for l.Len() > 0 {
+				l.Remove(l.Back())
+			}
+			assert.Nil(t, l.Front())
+			assert.Nil(t, l.Back())
+			assert.Equal(t, []int{}, l.Slice())
+
+			l.PushBack(8)
+			assert.Equal(t, l.Front(), l.Back())
+		})
+	}
+}
+
+func TestListZeroValue(t *testing.T) {
+	var l structs.List[string]
+	assert.False(t, l.Singly())
+
+	l.PushBack("b")
+	l.PushFront("a")
+	assert.Equal(t, []string{"b", "a"}, slices.Collect(l.Backward()))
+	assert.True(t, structs.NewSinglyLinkedList[string]().Singly())
+}
@@ -1,28 +1,182 @@
 package structs
 
-type Set struct {
-	hashMap map[interface{}]bool
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Set is an unordered collection of unique values.
+// The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	hashMap map[T]struct{}
 }
 
-// NewSet will initialize and return a new object of Set.
-func NewSet() *Set {
-	s := new(Set)
-	s.hashMap = make(map[interface{}]bool)
+// NewSet will initialize and return a new object of Set containing the given values.
+func NewSet[T comparable](values ...T) *Set[T] {
+	s := new(Set[T])
+	s.hashMap = make(map[T]struct{}, len(values))
+	for _, value := range values {
+		s.hashMap[value] = struct{}{}
+	}
 	return s
 }
 
 // Add will add the value in the Set.
-func (s *Set) Add(value interface{}) {
-	s.hashMap[value] = true
+func (s *Set[T]) Add(value T) {
+	if s.hashMap == nil {
+		s.hashMap = make(map[T]struct{})
+	}
+	s.hashMap[value] = struct{}{}
 }
 
 // Delete will delete the value from the set.
-func (s *Set) Delete(value interface{}) {
+func (s *Set[T]) Delete(value T) {
 	delete(s.hashMap, value)
 }
 
 // Exists will check if the value exists in the set or not.
-func (s *Set) Exists(value interface{}) bool {
+func (s *Set[T]) Exists(value T) bool {
 	_, ok := s.hashMap[value]
 	return ok
 }
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	return len(s.hashMap)
+}
+
+// All returns an iterator over the values of the set in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range s.hashMap {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new set with the same values.
+func (s *Set[T]) Clone() *Set[T] {
+	clone := NewSet[T]()
+	for value := range s.hashMap {
+		clone.hashMap[value] = struct{}{}
+	}
+	return clone
+}
+
+// Union returns a new set with the values that are in s or in other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	union := s.Clone()
+	for value := range other.hashMap {
+		union.hashMap[value] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns a new set with the values that are both in s and in other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	// iterate over the smaller set
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+
+	intersection := NewSet[T]()
+	for value := range small.hashMap {
+		if large.Exists(value) {
+			intersection.hashMap[value] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new set with the values that are in s but not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	difference := NewSet[T]()
+	for value := range s.hashMap {
+		if !other.Exists(value) {
+			difference.hashMap[value] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns a new set with the values that are in exactly one of s and other.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	difference := s.Difference(other)
+	for value := range other.hashMap {
+		if !s.Exists(value) {
+			difference.hashMap[value] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// IsSubset will check if every value of s exists in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for value := range s.hashMap {
+		if !other.Exists(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal will check if s and other contain exactly the same values.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// String returns the values of the set in their formatted order, e.g. {a, b, c}.
+func (s *Set[T]) String() string {
+	values := make([]string, 0, s.Len())
+	for value := range s.hashMap {
+		values = append(values, fmt.Sprint(value))
+	}
+	slices.Sort(values)
+	return "{" + strings.Join(values, ", ") + "}"
+}
+
+// MarshalJSON encodes the set as a JSON array.
+// The elements are ordered by their encoded form so the output is stable between runs.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	elements := make([][]byte, 0, s.Len())
+	for value := range s.hashMap {
+		element, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	slices.SortFunc(elements, bytes.Compare)
+	return append(append([]byte("["), bytes.Join(elements, []byte(","))...), ']'), nil
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its values.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = *NewSet(values...)
+	return nil
+}
+
+// SortedValues returns the values of the set in ascending order.
+func SortedValues[T cmp.Ordered](s *Set[T]) []T {
+	values := make([]T, 0, s.Len())
+	for value := range s.hashMap {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,128 @@
+package structs_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/adyanf/coding-patterns-dsa/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	testCases := []struct {
+		name                string
+		left                []int
+		right               []int
+		union               []int
+		intersection        []int
+		difference          []int
+		symmetricDifference []int
+		isSubset            bool
+		equal               bool
+	}{
+		{
+			name:                "Case 1",
+			left:                []int{1, 2, 3},
+			right:               []int{2, 3, 4},
+			union:               []int{1, 2, 3, 4},
+			intersection:        []int{2, 3},
+			difference:          []int{1},
+			symmetricDifference: []int{1, 4},
+			isSubset:            false,
+			equal:               false,
+		},
+		{
+			name:                "Case 2",
+			left:                []int{2, 3},
+			right:               []int{1, 2, 3},
+			union:               []int{1, 2, 3},
+			intersection:        []int{2, 3},
+			difference:          []int{},
+			symmetricDifference: []int{1},
+			isSubset:            true,
+			equal:               false,
+		},
+		{
+			name:                "Case 3",
+			left:                []int{3, 1, 2, 1},
+			right:               []int{1, 2, 3},
+			union:               []int{1, 2, 3},
+			intersection:        []int{1, 2, 3},
+			difference:          []int{},
+			symmetricDifference: []int{},
+			isSubset:            true,
+			equal:               true,
+		},
+		{
+			name:                "Case 4",
+			left:                []int{},
+			right:               []int{5},
+			union:               []int{5},
+			intersection:        []int{},
+			difference:          []int{},
+			symmetricDifference: []int{5},
+			isSubset:            true,
+			equal:               false,
+		},
+		{
+			name:                "Case 5",
+			left:                []int{-1, 0},
+			right:               []int{7, 8},
+			union:               []int{-1, 0, 7, 8},
+			intersection:        []int{},
+			difference:          []int{-1, 0},
+			symmetricDifference: []int{-1, 0, 7, 8},
+			isSubset:            false,
+			equal:               false,
+		},
+	}
+
+	for _, tc := range testCases {
+		left, right := structs.NewSet(tc.left...), structs.NewSet(tc.right...)
+
+		assert.Equal(t, tc.union, structs.SortedValues(left.Union(right)), tc.name)
+		assert.Equal(t, tc.intersection, structs.SortedValues(left.Intersection(right)), tc.name)
+		assert.Equal(t, tc.difference, structs.SortedValues(left.Difference(right)), tc.name)
+		assert.Equal(t, tc.symmetricDifference, structs.SortedValues(left.SymmetricDifference(right)), tc.name)
+		assert.Equal(t, tc.isSubset, left.IsSubset(right), tc.name)
+		assert.Equal(t, tc.equal, left.Equal(right), tc.name)
+
+		// the operations never modify their operands
+		assert.True(t, left.Equal(structs.NewSet(tc.left...)), tc.name)
+		assert.True(t, right.Equal(structs.NewSet(tc.right...)), tc.name)
+	}
+}
+
+func TestSet(t *testing.T) {
+	var s structs.Set[string]
+	assert.Equal(t, 0, s.Len())
+
+	s.Add("b")
+	s.Add("a")
+	s.Add("b")
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Exists("a"))
+	assert.Equal(t, "{a, b}", s.String())
+
+	clone := s.Clone()
+	clone.Delete("a")
+	assert.False(t, clone.Exists("a"))
+	assert.True(t, s.Exists("a"))
+
+	var values []string
+	for value := range s.All() {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	assert.Equal(t, []string{"a", "b"}, values)
+
+	data, err := json.Marshal(structs.NewSet("c", "a", "b"))
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b","c"]`, string(data))
+
+	decoded := structs.NewSet[string]()
+	assert.NoError(t, json.Unmarshal([]byte(`["x","y","x"]`), decoded))
+	assert.Equal(t, []string{"x", "y"}, structs.SortedValues(decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"x":1}`), decoded))
+}